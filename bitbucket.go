@@ -0,0 +1,260 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	bitbucketCloudHost    = "bitbucket.org"
+	bitbucketCloudAPIURL  = "https://api.bitbucket.org/2.0/"
+	bitbucketCloudMaxPage = 100
+)
+
+type (
+	bitbucketClient struct {
+		client  *http.Client
+		baseURL *url.URL
+		token   string
+		cloud   bool
+	}
+
+	bitbucketCloudTags struct {
+		Values []struct {
+			Name   string `json:"name"`
+			Target struct {
+				Hash string    `json:"hash"`
+				Date time.Time `json:"date"`
+			} `json:"target"`
+			Links struct {
+				HTML struct {
+					Href string `json:"href"`
+				} `json:"html"`
+			} `json:"links"`
+		} `json:"values"`
+		Next string `json:"next"`
+	}
+
+	bitbucketServerTags struct {
+		Values []struct {
+			DisplayID    string `json:"displayId"`
+			LatestCommit string `json:"latestCommit"`
+		} `json:"values"`
+		IsLastPage    bool `json:"isLastPage"`
+		NextPageStart int  `json:"nextPageStart"`
+	}
+)
+
+func init() {
+	RegisterDriver(DriverBitbucket, func(config DriverConfig) (Client, error) {
+		return NewBitbucketClient(config.URI, config.Token, config.HTTPClient)
+	})
+}
+
+// NewBitbucket creates a client for Bitbucket Cloud when the uri is
+// bitbucket.org and for Bitbucket Server or Data Center otherwise.
+//
+// The token is sent as a bearer token, unless it is in the form
+// <username>:<app-password> in which case it is sent as basic authentication.
+func NewBitbucket(uri, token string) (Client, error) {
+	return NewBitbucketClient(uri, token, http.DefaultClient)
+}
+
+func NewBitbucketClient(uri, token string, cl *http.Client) (Client, error) {
+	// Parse the url
+	bitbucketURL, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse bitbucket link: %w", err)
+	}
+
+	// Get base url for API
+	var baseURL *url.URL
+
+	hostname := bitbucketURL.Hostname()
+	cloud := hostname == bitbucketCloudHost || hostname == "api."+bitbucketCloudHost
+	if cloud {
+		baseURL, _ = url.Parse(bitbucketCloudAPIURL)
+	} else {
+		relBaseURL, _ := url.Parse("./rest/api/1.0/")
+		baseURL = bitbucketURL.ResolveReference(relBaseURL)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"bitbucket-url": bitbucketURL.String(),
+		"base-url":      baseURL.String(),
+		"cloud":         cloud,
+	}).Debug("connecting to bitbucket instance")
+
+	return &bitbucketClient{client: cl, baseURL: baseURL, token: token, cloud: cloud}, nil
+}
+
+// ListReleases falls back to tags as Bitbucket has no concept of a release.
+func (c *bitbucketClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	return c.ListTags(ctx, owner, name, opt)
+}
+
+func (c *bitbucketClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	logrus.WithFields(logrus.Fields{
+		"owner":    owner,
+		"name":     name,
+		"page":     opt.Page,
+		"cursor":   opt.Cursor,
+		"per-page": opt.PerPage,
+	}).Debug("listing bitbucket tags")
+
+	if opt.PerPage <= 0 {
+		opt.PerPage = perPageDefault
+	}
+
+	var r []Release
+	var resp *Response
+	var err error
+
+	if c.cloud {
+		r, resp, err = c.listCloudTags(ctx, owner, name, opt)
+	} else {
+		r, resp, err = c.listServerTags(ctx, owner, name, opt)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting tags from repository %s/%s: %w", owner, name, err)
+	}
+
+	return r, resp, nil
+}
+
+// listCloudTags lists tags using the 2.0 API. The cursor is the next link
+// returned by the previous page, which must be on the host of the API as the
+// token is sent along with it.
+func (c *bitbucketClient) listCloudTags(ctx context.Context, workspace, name string, opt ListOptions) ([]Release, *Response, error) {
+	u := opt.Cursor
+	if u != "" {
+		next, err := url.Parse(u)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not parse next page %s: %w", u, err)
+		}
+		if next.Scheme != c.baseURL.Scheme || next.Host != c.baseURL.Host {
+			return nil, nil, fmt.Errorf("next page %s is not on %s: %w", next.Redacted(), c.baseURL.Host, ErrScmDriver)
+		}
+	} else {
+		query := url.Values{}
+		query.Set("sort", "-target.date")
+		query.Set("pagelen", strconv.Itoa(min(opt.PerPage, bitbucketCloudMaxPage)))
+
+		u = c.resolve(fmt.Sprintf("repositories/%s/%s/refs/tags", url.PathEscape(workspace), url.PathEscape(name)), query)
+	}
+
+	var tags bitbucketCloudTags
+	if err := c.get(ctx, u, &tags); err != nil {
+		return nil, nil, err
+	}
+
+	r := make([]Release, 0, len(tags.Values))
+
+	for _, tag := range tags.Values {
+		tagName := tag.Name
+
+		logrus.WithFields(logrus.Fields{
+			"tag":    tagName,
+			"commit": tag.Target.Hash,
+		}).Debug("found tag")
+
+		r = append(r, Release{
+			Tag:         tagName,
+			SemVer:      generateVersion(tagName, versionMatcher),
+			Commit:      tag.Target.Hash,
+			URL:         tag.Links.HTML.Href,
+			PublishedAt: tag.Target.Date,
+		})
+	}
+
+	return r, &Response{NextCursor: tags.Next}, nil
+}
+
+// listServerTags lists tags using the 1.0 API. The cursor is the start of the
+// next page returned by the previous page.
+func (c *bitbucketClient) listServerTags(ctx context.Context, project, name string, opt ListOptions) ([]Release, *Response, error) {
+	start := opt.Cursor
+	if start == "" {
+		start = "0"
+	}
+
+	query := url.Values{}
+	query.Set("orderBy", "MODIFICATION")
+	query.Set("start", start)
+	query.Set("limit", strconv.Itoa(opt.PerPage))
+
+	var tags bitbucketServerTags
+	if err := c.get(ctx, c.resolve(fmt.Sprintf("projects/%s/repos/%s/tags", url.PathEscape(project), url.PathEscape(name)), query), &tags); err != nil {
+		return nil, nil, err
+	}
+
+	r := make([]Release, 0, len(tags.Values))
+
+	for _, tag := range tags.Values {
+		tagName := tag.DisplayID
+
+		logrus.WithFields(logrus.Fields{
+			"tag":    tagName,
+			"commit": tag.LatestCommit,
+		}).Debug("found tag")
+
+		r = append(r, Release{
+			Tag:    tagName,
+			SemVer: generateVersion(tagName, versionMatcher),
+			Commit: tag.LatestCommit,
+		})
+	}
+
+	if tags.IsLastPage {
+		return r, &Response{}, nil
+	}
+
+	return r, &Response{NextCursor: strconv.Itoa(tags.NextPageStart)}, nil
+}
+
+func (c *bitbucketClient) resolve(path string, query url.Values) string {
+	rel, _ := url.Parse(path)
+
+	u := c.baseURL.ResolveReference(rel)
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+func (c *bitbucketClient) get(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if username, password, ok := strings.Cut(c.token, ":"); ok {
+		req.SetBasicAuth(username, password)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	body, err := doRequest(c.client, req)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("could not decode bitbucket response: %w", err)
+	}
+
+	return nil
+}
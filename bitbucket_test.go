@@ -0,0 +1,247 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// rewriteTransport sends every request to the server while recording the url
+// originally requested.
+type rewriteTransport struct {
+	target *url.URL
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req.URL.String())
+	t.mu.Unlock()
+
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newBitbucketCloudServer serves the tags v1.<count-1>.0 to v1.0.0 of
+// owner/repo through the 2.0 API, linking to the next page with next.
+func newBitbucketCloudServer(t *testing.T, count int, next func(page int) string) (*http.Client, *rewriteTransport) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/repositories/owner/repo/refs/tags" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		pageLen, _ := strconv.Atoi(r.URL.Query().Get("pagelen"))
+
+		tags := []map[string]interface{}{}
+		for i := (page - 1) * pageLen; i < page*pageLen && i < count; i++ {
+			tags = append(tags, map[string]interface{}{
+				"name":   fmt.Sprintf("v1.%d.0", count-1-i),
+				"target": map[string]string{"hash": fmt.Sprintf("%040d", count-1-i)},
+			})
+		}
+
+		result := map[string]interface{}{"values": tags}
+		if page*pageLen < count {
+			result["next"] = next(page + 1)
+		}
+
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	transport := &rewriteTransport{target: target}
+
+	return &http.Client{Transport: transport}, transport
+}
+
+func TestBitbucketCloudPaging(t *testing.T) {
+	cl, transport := newBitbucketCloudServer(t, 70, func(page int) string {
+		return fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/owner/repo/refs/tags?pagelen=30&page=%d", page)
+	})
+
+	client, err := NewBitbucketClient("https://bitbucket.org", "secret", cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tags []string
+	for release, err := range Releases(client, ListReleaseOptions{Owner: "owner", Repo: "repo", Tags: true}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tags = append(tags, release.Tag)
+	}
+
+	if len(tags) != 70 || tags[0] != "v1.69.0" || tags[69] != "v1.0.0" {
+		t.Errorf("unexpected tags %v", tags)
+	}
+
+	expected := []string{
+		"https://api.bitbucket.org/2.0/repositories/owner/repo/refs/tags?pagelen=30&sort=-target.date",
+		"https://api.bitbucket.org/2.0/repositories/owner/repo/refs/tags?pagelen=30&page=2",
+		"https://api.bitbucket.org/2.0/repositories/owner/repo/refs/tags?pagelen=30&page=3",
+	}
+	if strings.Join(transport.requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests\n%s", strings.Join(transport.requests, "\n"))
+	}
+}
+
+func TestBitbucketCloudNextHost(t *testing.T) {
+	tests := []string{
+		"https://attacker.example.com/2.0/repositories/owner/repo/refs/tags?page=2",
+		"http://api.bitbucket.org/2.0/repositories/owner/repo/refs/tags?page=2",
+	}
+
+	for _, next := range tests {
+		cl, transport := newBitbucketCloudServer(t, 70, func(int) string { return next })
+
+		client, err := NewBitbucketClient("https://bitbucket.org", "secret", cl)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var failed error
+		for _, err := range Releases(client, ListReleaseOptions{Owner: "owner", Repo: "repo", Tags: true}) {
+			failed = err
+		}
+
+		// The token is never sent to another host
+		if failed == nil || len(transport.requests) != 1 {
+			t.Errorf("%s: expected an error after a single request, got %v after %d", next, failed, len(transport.requests))
+		}
+	}
+}
+
+// newBitbucketServer serves the tags v1.<count-1>.0 to v1.0.0 of PROJ/repo
+// through the 1.0 API.
+func newBitbucketServer(t *testing.T, count int) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bitbucket/rest/api/1.0/projects/PROJ/repos/repo/tags" {
+			http.NotFound(w, r)
+			return
+		}
+
+		mu.Lock()
+		requests = append(requests, r.URL.RawQuery)
+		mu.Unlock()
+
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "app-password" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		tags := []map[string]string{}
+		for i := start; i < start+limit && i < count; i++ {
+			tags = append(tags, map[string]string{
+				"displayId":    fmt.Sprintf("v1.%d.0", count-1-i),
+				"latestCommit": fmt.Sprintf("%040d", count-1-i),
+			})
+		}
+
+		page := map[string]interface{}{"values": tags, "isLastPage": start+limit >= count}
+		if start+limit < count {
+			page["nextPageStart"] = start + limit
+		}
+
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestBitbucketServerPaging(t *testing.T) {
+	server, requests := newBitbucketServer(t, 65)
+
+	client, err := NewBitbucketClient(server.URL+"/bitbucket/", "user:app-password", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tags []string
+	for release, err := range Releases(client, ListReleaseOptions{Owner: "PROJ", Repo: "repo", Tags: true}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tags = append(tags, release.Tag)
+	}
+
+	if len(tags) != 65 || tags[0] != "v1.64.0" || tags[64] != "v1.0.0" {
+		t.Errorf("unexpected tags %v", tags)
+	}
+
+	expected := "[limit=30&orderBy=MODIFICATION&start=0 limit=30&orderBy=MODIFICATION&start=30 limit=30&orderBy=MODIFICATION&start=60]"
+	if fmt.Sprint(*requests) != expected {
+		t.Errorf("unexpected requests %v", *requests)
+	}
+}
+
+func TestBitbucketServerLastPage(t *testing.T) {
+	server, _ := newBitbucketServer(t, 10)
+
+	client, err := NewBitbucketClient(server.URL+"/bitbucket/", "user:app-password", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags, resp, err := client.ListTags(context.Background(), "PROJ", "repo", ListOptions{PerPage: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tags) != 10 || tags[0].Commit != fmt.Sprintf("%040d", 9) {
+		t.Errorf("unexpected tags %+v", tags)
+	}
+	if resp.NextCursor != "" || resp.NextPage != 0 {
+		t.Errorf("expected the last page, got %+v", resp)
+	}
+}
+
+func TestBitbucketServerError(t *testing.T) {
+	server, _ := newBitbucketServer(t, 10)
+
+	client, err := NewBitbucketClient(server.URL+"/bitbucket/", "wrong", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.ListTags(context.Background(), "PROJ", "repo", ListOptions{}); err == nil {
+		t.Error("expected an error")
+	}
+}
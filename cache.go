@@ -0,0 +1,280 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const cacheFileExt = ".http"
+
+// Request headers that change the response and so are part of the cache key
+var cacheKeyHeaders = []string{"Accept", "Authorization", "Private-Token", "Git-Protocol"}
+
+type (
+	// Cache stores responses on disk so later requests can be revalidated
+	// with conditional requests rather than downloaded again.
+	Cache struct {
+		// Dir is the directory the responses are stored in.
+		Dir string
+		// TTL is how long a stored response is used without revalidating it.
+		// When zero every request is revalidated.
+		TTL time.Duration
+	}
+
+	// CacheStats describes the contents of a Cache.
+	CacheStats struct {
+		// Entries is the number of stored responses.
+		Entries int
+		// Size is the total size of the stored responses in bytes.
+		Size int64
+		// Oldest is when the least recently validated response was stored.
+		Oldest time.Time
+		// Newest is when the most recently validated response was stored.
+		Newest time.Time
+	}
+
+	cacheTransport struct {
+		base  http.RoundTripper
+		cache *Cache
+	}
+)
+
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl}
+}
+
+// Transport wraps the transport so successful GET requests are stored in the
+// cache.
+//
+// Stored responses are sent with If-None-Match and If-Modified-Since headers
+// and a 304 Not Modified response is served from disk.
+func (c *Cache) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &cacheTransport{base: base, cache: c}
+}
+
+// Clear removes all the stored responses.
+func (c *Cache) Clear() error {
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err = os.Remove(filepath.Join(c.Dir, entry.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("could not remove cache entry %s: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// Stats reads the contents of the cache.
+func (c *Cache) Stats() (CacheStats, error) {
+	var stats CacheStats
+
+	entries, err := c.entries()
+	if err != nil {
+		return stats, err
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		stats.Entries++
+		stats.Size += info.Size()
+
+		if stats.Oldest.IsZero() || info.ModTime().Before(stats.Oldest) {
+			stats.Oldest = info.ModTime()
+		}
+		if info.ModTime().After(stats.Newest) {
+			stats.Newest = info.ModTime()
+		}
+	}
+
+	return stats, nil
+}
+
+func (c *Cache) entries() ([]fs.DirEntry, error) {
+	entries, err := os.ReadDir(c.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read cache directory %s: %w", c.Dir, err)
+	}
+
+	var r []fs.DirEntry
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), cacheFileExt) {
+			r = append(r, entry)
+		}
+	}
+
+	return r, nil
+}
+
+func (c *Cache) path(req *http.Request) string {
+	h := sha256.New()
+	fmt.Fprintln(h, req.Method, req.URL.String())
+	for _, key := range cacheKeyHeaders {
+		fmt.Fprintln(h, key, req.Header.Get(key))
+	}
+
+	return filepath.Join(c.Dir, hex.EncodeToString(h.Sum(nil))+cacheFileExt)
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only plain GET requests are cached
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	path := t.cache.path(req)
+	log := logrus.WithField("url", req.URL.Redacted())
+
+	cached, storedAt, err := readCachedResponse(path, req)
+	if err != nil {
+		log.WithError(err).Debug("ignoring cache entry")
+	}
+
+	if cached != nil && time.Since(storedAt) < t.cache.TTL {
+		log.Debug("serving response from cache")
+
+		return cached, nil
+	}
+
+	conditional := req
+	if cached != nil {
+		conditional = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			conditional.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			conditional.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(conditional)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+
+		log.Debug("serving revalidated response from cache")
+
+		// The quota comes from the response that was actually received
+		for _, headers := range [][]string{rateLimitLimitHeaders, rateLimitRemainingHeaders, rateLimitResetHeaders} {
+			for _, key := range headers {
+				if value := resp.Header.Get(key); value != "" {
+					cached.Header.Set(key, value)
+				}
+			}
+		}
+
+		now := time.Now()
+		if err = os.Chtimes(path, now, now); err != nil {
+			log.WithError(err).Debug("could not update cache entry")
+		}
+
+		return cached, nil
+	}
+
+	if resp.StatusCode != http.StatusOK || (resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" && t.cache.TTL == 0) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not read response from %s: %w", req.URL.Redacted(), err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.TransferEncoding = nil
+
+	if err = writeCachedResponse(path, resp, body); err != nil {
+		log.WithError(err).Warn("could not store response in cache")
+	}
+
+	return resp, nil
+}
+
+func readCachedResponse(path string, req *http.Request) (*http.Response, time.Time, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, time.Time{}, nil
+	} else if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), req)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	// The stored quota is out of date
+	for _, headers := range [][]string{rateLimitLimitHeaders, rateLimitRemainingHeaders, rateLimitResetHeaders} {
+		for _, key := range headers {
+			resp.Header.Del(key)
+		}
+	}
+
+	return resp, info.ModTime(), nil
+}
+
+func writeCachedResponse(path string, resp *http.Response, body []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	stored := *resp
+	stored.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err = stored.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const cacheTestETag = `"v1"`
+
+// newETagServer serves a body with an ETag, answering a matching
+// If-None-Match with 304 Not Modified.
+func newETagServer(t *testing.T) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+
+	var requests, notModified atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		if r.Header.Get("If-None-Match") == cacheTestETag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", cacheTestETag)
+		_, _ = io.WriteString(w, "releases")
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests, &notModified
+}
+
+func getBody(t *testing.T, client *http.Client, url string) (string, *http.Response) {
+	t.Helper()
+
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(body), resp
+}
+
+func TestCacheRevalidates(t *testing.T) {
+	server, requests, notModified := newETagServer(t)
+
+	cache := NewCache(t.TempDir(), 0)
+	client := &http.Client{Transport: cache.Transport(nil)}
+
+	for i := 0; i < 3; i++ {
+		body, resp := getBody(t, client, server.URL)
+		if body != "releases" || resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected response %s %q", resp.Status, body)
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") != "42" {
+			t.Errorf("expected the quota of the response, got %q", resp.Header.Get("X-RateLimit-Remaining"))
+		}
+	}
+
+	if requests.Load() != 3 || notModified.Load() != 2 {
+		t.Errorf("expected 2 of 3 requests to be revalidated, got %d of %d", notModified.Load(), requests.Load())
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 1 || stats.Size == 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	if err = cache.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if stats, _ = cache.Stats(); stats.Entries != 0 {
+		t.Errorf("expected an empty cache, got %+v", stats)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	server, requests, _ := newETagServer(t)
+
+	client := &http.Client{Transport: NewCache(t.TempDir(), time.Hour).Transport(nil)}
+
+	for i := 0; i < 3; i++ {
+		if body, _ := getBody(t, client, server.URL); body != "releases" {
+			t.Fatalf("unexpected body %q", body)
+		}
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("expected responses within the ttl to be served from disk, got %d requests", requests.Load())
+	}
+}
+
+func TestCacheKeyHeaders(t *testing.T) {
+	server, requests, notModified := newETagServer(t)
+
+	client := &http.Client{Transport: NewCache(t.TempDir(), time.Hour).Transport(nil)}
+
+	for _, token := range []string{"token a", "token b"} {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", token)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	if requests.Load() != 2 || notModified.Load() != 0 {
+		t.Errorf("expected responses to be stored per token, got %d requests", requests.Load())
+	}
+}
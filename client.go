@@ -8,6 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"time"
 
 	"github.com/Masterminds/semver"
 )
@@ -16,6 +19,22 @@ type (
 	Release struct {
 		Tag    string
 		SemVer *semver.Version
+		// Version read by the Scheme of the library. Releases without a version
+		// in the scheme are never compared.
+		Version Version
+		// VersionText is the version within the tag when the tag is not a
+		// version itself, such as the version captured from a file name.
+		VersionText string
+		// Commit the release points to when known.
+		Commit string
+		// URL of the release on the web when known.
+		URL string
+		// PublishedAt is when the release was published when known.
+		PublishedAt time.Time
+		// Draft is set when the release is marked as a draft upstream.
+		Draft bool
+		// Prerelease is set when the release is marked as a pre-release upstream.
+		Prerelease bool
 	}
 
 	ListOptions struct {
@@ -23,30 +42,113 @@ type (
 		Page int
 		// For paginated result sets, the number of results to include per page.
 		PerPage int
+		// For cursor paginated result sets, the opaque cursor of the results to
+		// retrieve. Takes precedence over Page when set.
+		Cursor string
 	}
 
+	Response struct {
+		// For paginated result sets, the page of results that follows. Zero when
+		// there are no more results.
+		NextPage int
+		// For cursor paginated result sets, the cursor of the results that
+		// follow. Empty when there are no more results.
+		NextCursor string
+	}
+
+	// ClientOptions holds settings for creating a client.
+	ClientOptions struct {
+		// Pattern matching the file names within a directory index.
+		Pattern string
+		// Retry configures how failed requests are retried. When not set the
+		// DefaultRetryOptions are used.
+		Retry *RetryOptions
+		// Cache stores responses on disk when set.
+		Cache *Cache
+		// GraphQL uses the GraphQL API of GitHub rather than the REST API.
+		GraphQL bool
+		// Options holds driver specific settings passed to the DriverFactory.
+		Options map[string]interface{}
+	}
+
+	// Client lists the releases and tags of a repository a page at a time.
+	// The Response describes the page that follows, and a nil Response is
+	// treated as the last page.
 	Client interface {
-		ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, error)
+		ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error)
+
+		ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error)
+	}
 
-		ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, error)
+	// TagResolver is implemented by clients that can look up the commit of a
+	// tag when the releases they list do not include it.
+	TagResolver interface {
+		ResolveTag(ctx context.Context, owner, name, tag string) (string, error)
 	}
 )
 
 var ErrScmDriver = errors.New("scm driver error")
 
+var commitMatcher = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
 const (
-	DriverGitHub = "github"
-	DriverGitLab = "gitlab"
+	DriverGitHub    = "github"
+	DriverGitLab    = "gitlab"
+	DriverGitea     = "gitea"
+	DriverGit       = "git"
+	DriverHTTPIndex = "httpindex"
+	DriverBitbucket = "bitbucket"
 )
 
+// NewClientFromDriver creates a client for the scm using the default options.
 func NewClientFromDriver(driver, uri, token string) (Client, error) {
-	if driver == DriverGitHub {
-		return NewGitHub(uri, token)
+	return NewClientFromDriverWithOptions(driver, uri, token, ClientOptions{})
+}
+
+// NewClientFromDriverWithOptions creates a client for the scm. Requests are
+// retried and cached as configured by the options.
+func NewClientFromDriverWithOptions(driver, uri, token string, opts ClientOptions) (Client, error) {
+	retry := DefaultRetryOptions()
+	if opts.Retry != nil {
+		retry = *opts.Retry
 	}
 
-	if driver == DriverGitLab {
-		return NewGitLab(uri, token)
+	transport := NewRetryTransport(http.DefaultTransport, retry)
+	if opts.Cache != nil {
+		transport = opts.Cache.Transport(transport)
+	}
+
+	factory, ok := driverFactory(driver)
+	if !ok {
+		return nil, fmt.Errorf("unknown scm driver %s: %w", driver, ErrScmDriver)
+	}
+
+	// Settings with a field of their own are passed as options
+	options := make(map[string]interface{}, len(opts.Options)+2)
+	for key, val := range opts.Options {
+		options[key] = val
+	}
+	if opts.Pattern != "" {
+		options["pattern"] = opts.Pattern
+	}
+	if opts.GraphQL {
+		options["graphql"] = true
+	}
+
+	return factory(DriverConfig{
+		URI:        uri,
+		Token:      token,
+		HTTPClient: &http.Client{Transport: transport},
+		Options:    options,
+	})
+}
+
+// commitOrEmpty returns the reference when it is the SHA of a commit rather
+// than a branch name.
+func commitOrEmpty(ref string) string {
+	if commitMatcher.MatchString(ref) {
+		return ref
 	}
 
-	return nil, fmt.Errorf("unknown scm driver %s: %w", driver, ErrScmDriver)
+	return ""
 }
@@ -0,0 +1,144 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/WebKitForWindows/reqcheck"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+)
+
+func cacheCmd() *cli.Command {
+	var configPath string
+
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "manage the response cache",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "config-path",
+				Usage:       "path to the directory containing the " + configFileName + " configuring the cache",
+				Value:       ".",
+				Destination: &configPath,
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "clear",
+				Usage: "remove all cached responses",
+				Action: func(c context.Context, cmd *cli.Command) error {
+					cache, err := requireCache(cmd, configPath)
+					if err != nil {
+						return err
+					}
+
+					if err = cache.Clear(); err != nil {
+						return fmt.Errorf("could not clear cache: %w", err)
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:  "stats",
+				Usage: "show the contents of the cache",
+				Action: func(c context.Context, cmd *cli.Command) error {
+					cache, err := requireCache(cmd, configPath)
+					if err != nil {
+						return err
+					}
+
+					stats, err := cache.Stats()
+					if err != nil {
+						return fmt.Errorf("could not read cache: %w", err)
+					}
+
+					if format := cmd.String("format"); format != formatText {
+						return writeResults(os.Stdout, format, []cacheStatsResult{newCacheStatsResult(cache, stats)})
+					}
+
+					fmt.Printf("directory: %s\n", cache.Dir)
+					fmt.Printf("entries: %d\n", stats.Entries)
+					fmt.Printf("size: %d bytes\n", stats.Size)
+					if stats.Entries > 0 {
+						fmt.Printf("oldest: %s\n", stats.Oldest.Format(time.RFC3339))
+						fmt.Printf("newest: %s\n", stats.Newest.Format(time.RFC3339))
+					}
+
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// cacheFromFlags returns the cache given on the command line if any.
+func cacheFromFlags(cmd *cli.Command) *reqcheck.Cache {
+	dir := cmd.String("cache-dir")
+	if dir == "" {
+		return nil
+	}
+
+	return reqcheck.NewCache(dir, cmd.Duration("cache-ttl"))
+}
+
+// resolveCache determines the cache with the command line taking precedence
+// over the config. A relative directory in the config is within the base
+// path.
+func resolveCache(cmd *cli.Command, cfg config, basePath string) *reqcheck.Cache {
+	cache := cacheFromFlags(cmd)
+	if cache == nil && cfg.Cache.Dir != "" {
+		cacheDir := cfg.Cache.Dir
+		if !filepath.IsAbs(cacheDir) {
+			cacheDir = filepath.Join(basePath, cacheDir)
+		}
+
+		cache = reqcheck.NewCache(cacheDir, cfg.Cache.TTL)
+	}
+	if cache != nil && !cmd.IsSet("cache-ttl") && cfg.Cache.TTL > 0 {
+		cache.TTL = cfg.Cache.TTL
+	}
+
+	if cache != nil {
+		logrus.WithFields(logrus.Fields{
+			"cache-dir": cache.Dir,
+			"ttl":       cache.TTL,
+		}).Debug("cache")
+	}
+
+	return cache
+}
+
+// requireCache determines the cache in the same way as the commands filling
+// it, reading the config within the path when present.
+func requireCache(cmd *cli.Command, configPath string) (*reqcheck.Cache, error) {
+	var cfg config
+	if cmd.String("cache-dir") == "" {
+		var err error
+		cfg, err = loadConfig(filepath.Join(configPath, configFileName))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("could not open config file %s: %w", configFileName, err)
+		}
+	}
+
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine config path: %w", err)
+	}
+
+	cache := resolveCache(cmd, cfg, absPath)
+	if cache == nil {
+		return nil, fmt.Errorf("no cache directory provided: %w", ErrCli)
+	}
+
+	return cache, nil
+}
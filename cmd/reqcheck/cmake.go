@@ -0,0 +1,298 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type (
+	// cmakeCall is a command invocation within a CMake script.
+	cmakeCall struct {
+		Name string
+		Args []cmakeArg
+	}
+
+	// cmakeArg is an argument of a command invocation. The start and end are
+	// the offsets of the argument in the script including any quotes.
+	cmakeArg struct {
+		Value  string
+		Quoted bool
+		Start  int
+		End    int
+	}
+)
+
+// cmakeKeywordRegex matches the arguments treated as keywords when collecting
+// the values following a keyword.
+var cmakeKeywordRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// cmakeVariableRegex matches a variable reference.
+var cmakeVariableRegex = regexp.MustCompile(`\$\{([^${}]+)\}`)
+
+// cmakeBOM is the byte order mark an editor may write at the start of a
+// script.
+var cmakeBOM = []byte("\xef\xbb\xbf")
+
+// parseCMake reads the command invocations of a CMake script. Control flow
+// is not evaluated so every invocation is returned in the order written. Any
+// text outside of an invocation which CMake would reject is skipped.
+func parseCMake(src []byte) ([]cmakeCall, error) {
+	var calls []cmakeCall
+
+	// Offsets remain relative to the start of the script
+	i := 0
+	if bytes.HasPrefix(src, cmakeBOM) {
+		i = len(cmakeBOM)
+	}
+
+	for i < len(src) {
+		c := src[i]
+
+		switch {
+		case isCMakeSpace(c):
+			i++
+		case c == '#':
+			i = skipCMakeComment(src, i)
+		case isCMakeIdentifier(c) && (c < '0' || c > '9'):
+			start := i
+			for i < len(src) && isCMakeIdentifier(src[i]) {
+				i++
+			}
+			name := string(src[start:i])
+
+			for i < len(src) && (src[i] == ' ' || src[i] == '\t') {
+				i++
+			}
+			if i == len(src) || src[i] != '(' {
+				return nil, fmt.Errorf("expected ( after %s on line %d: %w", name, cmakeLine(src, i), ErrCli)
+			}
+
+			args, next, err := parseCMakeArgs(src, i+1)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse arguments of %s on line %d: %w", name, cmakeLine(src, start), err)
+			}
+
+			calls = append(calls, cmakeCall{Name: name, Args: args})
+			i = next
+		default:
+			i++
+		}
+	}
+
+	return calls, nil
+}
+
+// parseCMakeArgs reads the arguments of an invocation starting after the
+// opening parenthesis. It returns the offset following the closing
+// parenthesis.
+func parseCMakeArgs(src []byte, i int) ([]cmakeArg, int, error) {
+	var args []cmakeArg
+	depth := 0
+
+	for i < len(src) {
+		c := src[i]
+
+		switch {
+		case isCMakeSpace(c):
+			i++
+		case c == '#':
+			i = skipCMakeComment(src, i)
+		case c == '(':
+			depth++
+			i++
+		case c == ')':
+			if depth == 0 {
+				return args, i + 1, nil
+			}
+			depth--
+			i++
+		case c == '"':
+			var sb strings.Builder
+			start := i
+			i++
+			for i < len(src) && src[i] != '"' {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+					sb.WriteString(cmakeEscape(src[i]))
+				} else {
+					sb.WriteByte(src[i])
+				}
+				i++
+			}
+			if i == len(src) {
+				return nil, i, fmt.Errorf("unterminated quoted argument on line %d: %w", cmakeLine(src, start), ErrCli)
+			}
+			i++
+
+			args = append(args, cmakeArg{Value: sb.String(), Quoted: true, Start: start, End: i})
+		default:
+			if level, ok := cmakeBracketOpen(src, i); ok {
+				start := i
+				end, closeEnd := cmakeBracketClose(src, i+level+2, level)
+				if end < 0 {
+					return nil, i, fmt.Errorf("unterminated bracket argument on line %d: %w", cmakeLine(src, start), ErrCli)
+				}
+
+				// A newline directly after the opening bracket is ignored
+				value := strings.TrimPrefix(string(src[start+level+2:end]), "\n")
+				args = append(args, cmakeArg{Value: value, Quoted: true, Start: start, End: closeEnd})
+				i = closeEnd
+				continue
+			}
+
+			var sb strings.Builder
+			start := i
+			for i < len(src) && !isCMakeSpace(src[i]) && src[i] != '(' && src[i] != ')' {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+					sb.WriteString(cmakeEscape(src[i]))
+				} else {
+					sb.WriteByte(src[i])
+				}
+				i++
+			}
+
+			args = append(args, cmakeArg{Value: sb.String(), Start: start, End: i})
+		}
+	}
+
+	return nil, i, fmt.Errorf("missing closing parenthesis: %w", ErrCli)
+}
+
+// keyword returns the argument following the keyword.
+func (c cmakeCall) keyword(name string) (cmakeArg, bool) {
+	values := c.values(name)
+	if len(values) == 0 {
+		return cmakeArg{}, false
+	}
+
+	return values[0], true
+}
+
+// values returns the arguments following the keyword up to the next keyword.
+// Any unquoted argument in upper case is considered a keyword.
+func (c cmakeCall) values(name string) []cmakeArg {
+	for i, arg := range c.Args {
+		if arg.Quoted || arg.Value != name {
+			continue
+		}
+
+		end := i + 1
+		for end < len(c.Args) && (c.Args[end].Quoted || !cmakeKeywordRegex.MatchString(c.Args[end].Value)) {
+			end++
+		}
+
+		return c.Args[i+1 : end]
+	}
+
+	return nil
+}
+
+// expandCMake replaces the variable references within the value. Any
+// variable not provided is an error.
+func expandCMake(value string, vars map[string]string) (string, error) {
+	var err error
+
+	expanded := cmakeVariableRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		name := cmakeVariableRegex.FindStringSubmatch(ref)[1]
+		v, ok := vars[name]
+		if !ok && err == nil {
+			err = fmt.Errorf("could not expand variable %s: %w", name, ErrCli)
+		}
+
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return expanded, nil
+}
+
+// quoteCMake writes the value as a quoted argument.
+func quoteCMake(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func skipCMakeComment(src []byte, i int) int {
+	if level, ok := cmakeBracketOpen(src, i+1); ok {
+		if _, end := cmakeBracketClose(src, i+level+3, level); end >= 0 {
+			return end
+		}
+
+		return len(src)
+	}
+
+	if end := bytes.IndexByte(src[i:], '\n'); end >= 0 {
+		return i + end + 1
+	}
+
+	return len(src)
+}
+
+// cmakeBracketOpen determines if a bracket such as [[ or [=[ starts at the
+// offset returning the number of equals signs.
+func cmakeBracketOpen(src []byte, i int) (int, bool) {
+	if i >= len(src) || src[i] != '[' {
+		return 0, false
+	}
+
+	level := 0
+	for i+1+level < len(src) && src[i+1+level] == '=' {
+		level++
+	}
+
+	if i+1+level < len(src) && src[i+1+level] == '[' {
+		return level, true
+	}
+
+	return 0, false
+}
+
+// cmakeBracketClose finds the bracket closing one with the given level. It
+// returns the offsets of the start and end of the closing bracket.
+func cmakeBracketClose(src []byte, i, level int) (int, int) {
+	closing := []byte("]" + strings.Repeat("=", level) + "]")
+
+	end := bytes.Index(src[min(i, len(src)):], closing)
+	if end < 0 {
+		return -1, -1
+	}
+
+	end += min(i, len(src))
+
+	return end, end + len(closing)
+}
+
+func cmakeEscape(c byte) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 't':
+		return "\t"
+	case 'r':
+		return "\r"
+	case '\n':
+		return ""
+	}
+
+	return string(c)
+}
+
+func cmakeLine(src []byte, i int) int {
+	return bytes.Count(src[:min(i, len(src))], []byte("\n")) + 1
+}
+
+func isCMakeSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+func isCMakeIdentifier(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+const testPortfile = `# Fetch the sources
+vcpkg_from_github(
+    OUT_SOURCE_PATH SOURCE_PATH
+    REPO curl/curl
+    REF "curl-${VERSION}"
+    SHA512 0123 # hash of the archive
+    PATCHES
+        fix-build.patch
+        [[bracket (argument)]]
+)
+
+vcpkg_cmake_configure(SOURCE_PATH "${SOURCE_PATH}" OPTIONS -DBUILD_TESTING=OFF)
+`
+
+func TestParseCMake(t *testing.T) {
+	calls, err := parseCMake([]byte(testPortfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != 2 || calls[0].Name != "vcpkg_from_github" || calls[1].Name != "vcpkg_cmake_configure" {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+
+	ref, ok := calls[0].keyword("REF")
+	if !ok || ref.Value != "curl-${VERSION}" || !ref.Quoted || testPortfile[ref.Start:ref.End] != `"curl-${VERSION}"` {
+		t.Errorf("unexpected REF %+v", ref)
+	}
+
+	var patches []string
+	for _, arg := range calls[0].values("PATCHES") {
+		patches = append(patches, arg.Value)
+	}
+	if fmt.Sprint(patches) != "[fix-build.patch bracket (argument)]" {
+		t.Errorf("unexpected patches %v", patches)
+	}
+}
+
+func TestParseCMakeSkipsUnknownText(t *testing.T) {
+	tests := map[string]string{
+		"byte order mark": "\xef\xbb\xbfvcpkg_from_github(REPO curl/curl)\n",
+		"non-ascii":       "# Überprüfung\n§ ¶\nvcpkg_from_github(REPO curl/curl)\n",
+		"stray bytes":     "}\x00\nvcpkg_from_github(REPO curl/curl)\n",
+	}
+
+	for name, script := range tests {
+		calls, err := parseCMake([]byte(script))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+
+		if len(calls) != 1 {
+			t.Errorf("%s: expected 1 call, got %d", name, len(calls))
+			continue
+		}
+
+		// Offsets are within the original script
+		repo, _ := calls[0].keyword("REPO")
+		if script[repo.Start:repo.End] != "curl/curl" {
+			t.Errorf("%s: unexpected offsets %d to %d", name, repo.Start, repo.End)
+		}
+	}
+}
+
+func TestParseCMakeErrors(t *testing.T) {
+	tests := []string{
+		"vcpkg_from_github(REPO curl/curl\n",
+		"vcpkg_from_github(REF \"unterminated)\n",
+		"vcpkg_from_github\nset(VERSION 1.0)\n",
+	}
+
+	for _, script := range tests {
+		if _, err := parseCMake([]byte(script)); err == nil {
+			t.Errorf("expected an error for %q", script)
+		}
+	}
+}
+
+func TestExpandCMake(t *testing.T) {
+	vars := map[string]string{"PORT": "curl", "VERSION": "8.10.0"}
+
+	expanded, err := expandCMake("${PORT}-${VERSION}.tar.gz", vars)
+	if err != nil || expanded != "curl-8.10.0.tar.gz" {
+		t.Errorf("unexpected expansion %s %v", expanded, err)
+	}
+
+	if _, err = expandCMake("${CURRENT_PACKAGES_DIR}/lib", vars); err == nil {
+		t.Error("expected an error for an unknown variable")
+	}
+}
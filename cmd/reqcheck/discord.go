@@ -0,0 +1,144 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// discordMaxEmbeds is the most embeds Discord accepts in a message.
+	discordMaxEmbeds = 10
+	// discordMaxDescription is the most characters Discord accepts in the
+	// description of an embed.
+	discordMaxDescription = 4096
+	// discordMaxMessage is the most characters Discord accepts across all the
+	// embeds of a message.
+	discordMaxMessage = 6000
+)
+
+// Colors of the embeds for each state
+const (
+	discordColorUpgrade = 0xf1c40f
+	discordColorError   = 0xe74c3c
+	discordColorCurrent = 0x2ecc71
+)
+
+type (
+	// discordNotifier posts embeds to a Discord webhook.
+	discordNotifier struct {
+		Webhook  string
+		Username string
+	}
+
+	discordMessage struct {
+		Username string         `json:"username,omitempty"`
+		Content  string         `json:"content"`
+		Embeds   []discordEmbed `json:"embeds,omitempty"`
+	}
+
+	discordEmbed struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Color       int    `json:"color"`
+	}
+)
+
+func newDiscordNotifier(un map[string]interface{}) (notifier, error) {
+	webhook, err := requireString(un, "webhook")
+	if err != nil {
+		return nil, err
+	}
+
+	username, _ := un["username"].(string)
+
+	return discordNotifier{Webhook: webhook, Username: username}, nil
+}
+
+func (n discordNotifier) messages(r report) ([][]byte, error) {
+	var embeds []discordEmbed
+
+	var lines []string
+	for _, release := range r.Upgrade {
+		latest := release.Upgrade
+		if release.URL != "" {
+			latest = fmt.Sprintf("[%s](%s)", release.Upgrade, release.URL)
+		}
+
+		line := fmt.Sprintf("**%s**: %s → %s", release.Name, release.currentString(), latest)
+		if !release.PublishedAt.IsZero() {
+			line += fmt.Sprintf(" (published %s)", release.PublishedAt.Format(time.DateOnly))
+		}
+
+		lines = append(lines, line)
+	}
+	embeds = appendDiscordEmbeds(embeds, "The following libraries have updates", discordColorUpgrade, lines)
+
+	lines = nil
+	for _, failure := range r.Errors {
+		lines = append(lines, fmt.Sprintf("**%s**: %s", failure.Name, failure.Reason))
+	}
+	embeds = appendDiscordEmbeds(embeds, "The following libraries could not be checked", discordColorError, lines)
+
+	lines = nil
+	for _, release := range r.Current {
+		lines = append(lines, fmt.Sprintf("%s %s", release.Name, release.currentString()))
+	}
+	embeds = appendDiscordEmbeds(embeds, "The following libraries are up to date", discordColorCurrent, lines)
+
+	// Split the embeds into messages within the limits
+	messages := []discordMessage{{Username: n.Username, Content: "Requirements check: " + r.summary()}}
+	size := 0
+
+	for _, embed := range embeds {
+		last := &messages[len(messages)-1]
+		embedSize := len(embed.Title) + len(embed.Description)
+
+		if len(last.Embeds) == discordMaxEmbeds || (len(last.Embeds) > 0 && size+embedSize > discordMaxMessage) {
+			messages = append(messages, discordMessage{Username: n.Username})
+			last = &messages[len(messages)-1]
+			size = 0
+		}
+
+		last.Embeds = append(last.Embeds, embed)
+		size += embedSize
+	}
+
+	var bodies [][]byte
+	for _, message := range messages {
+		b, err := marshalMessage(message)
+		if err != nil {
+			return nil, err
+		}
+
+		bodies = append(bodies, b)
+	}
+
+	return bodies, nil
+}
+
+func (n discordNotifier) send(ctx context.Context, cl *http.Client, body []byte) error {
+	_, err := postBody(ctx, cl, http.MethodPost, n.Webhook, nil, body)
+
+	return err
+}
+
+// appendDiscordEmbeds adds embeds listing the lines, continuing in further
+// embeds when the description is full.
+func appendDiscordEmbeds(embeds []discordEmbed, title string, color int, lines []string) []discordEmbed {
+	for i, description := range splitLines(lines, discordMaxDescription) {
+		embed := discordEmbed{Title: title, Description: description, Color: color}
+		if i > 0 {
+			embed.Title = title + " (continued)"
+		}
+
+		embeds = append(embeds, embed)
+	}
+
+	return embeds
+}
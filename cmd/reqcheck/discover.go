@@ -0,0 +1,231 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/WebKitForWindows/reqcheck"
+	"github.com/sirupsen/logrus"
+)
+
+// Hosts used by the vcpkg helpers when none is given
+const (
+	defaultGitHubHost    = "https://github.com"
+	defaultBitbucketHost = "https://bitbucket.org"
+)
+
+// resolveLibrary fills in the upstream of a library from the portfile when
+// the host or repo is not configured. Any setting in the config takes
+// precedence over what is discovered.
+func (v vcpkgRepository) resolveLibrary(name string, l library) (library, error) {
+	if l.Host != "" && l.Repo != "" {
+		return l, nil
+	}
+
+	portPath, _, err := v.findPort(name)
+	if err != nil {
+		return l, err
+	}
+
+	repoURL, err := readPortUpstream(portPath, name)
+	if err != nil {
+		return l, fmt.Errorf("could not determine upstream of %s: %w", name, err)
+	}
+
+	host, owner, repo, err := v.Config.scmForURL(repoURL)
+	if err != nil {
+		return l, fmt.Errorf("could not determine scm of %s: %w", name, err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"library": name,
+		"url":     repoURL,
+		"host":    host,
+		"owner":   owner,
+		"repo":    repo,
+	}).Debug("discovered upstream")
+
+	if l.Host == "" {
+		l.Host = host
+	}
+	if l.Owner == "" {
+		l.Owner = owner
+	}
+	if l.Repo == "" {
+		l.Repo = repo
+	}
+
+	return l, nil
+}
+
+// readPortUpstream determines the repository a port downloads its sources
+// from using the first vcpkg_from_github, vcpkg_from_gitlab,
+// vcpkg_from_bitbucket or vcpkg_from_git call within the portfile.
+func readPortUpstream(portPath, name string) (string, error) {
+	portfile, err := os.ReadFile(filepath.Join(portPath, "portfile.cmake"))
+	if err != nil {
+		return "", fmt.Errorf("could not read portfile: %w", err)
+	}
+
+	calls, err := parseCMake(portfile)
+	if err != nil {
+		return "", fmt.Errorf("could not parse portfile: %w", err)
+	}
+
+	vars := map[string]string{"PORT": name}
+
+	for _, call := range calls {
+		var hostArg, repoArg string
+		var defaultHost string
+
+		// Commands are case insensitive
+		switch strings.ToLower(call.Name) {
+		case "vcpkg_from_github":
+			hostArg, repoArg, defaultHost = "GITHUB_HOST", "REPO", defaultGitHubHost
+		case "vcpkg_from_gitlab":
+			hostArg, repoArg = "GITLAB_URL", "REPO"
+		case "vcpkg_from_bitbucket":
+			repoArg, defaultHost = "REPO", defaultBitbucketHost
+		case "vcpkg_from_git":
+			repoArg = "URL"
+		default:
+			continue
+		}
+
+		repo, ok := call.keyword(repoArg)
+		if !ok {
+			return "", fmt.Errorf("no %s provided to %s: %w", repoArg, call.Name, ErrCli)
+		}
+		repoURL, err := expandCMake(repo.Value, vars)
+		if err != nil {
+			return "", err
+		}
+
+		if hostArg == "" && defaultHost == "" {
+			return repoURL, nil
+		}
+
+		host := defaultHost
+		if arg, ok := call.keyword(hostArg); ok {
+			if host, err = expandCMake(arg.Value, vars); err != nil {
+				return "", err
+			}
+		}
+		if host == "" {
+			return "", fmt.Errorf("no %s provided to %s: %w", hostArg, call.Name, ErrCli)
+		}
+
+		return strings.TrimSuffix(host, "/") + "/" + strings.Trim(repoURL, "/"), nil
+	}
+
+	return "", fmt.Errorf("no source repository found: %w", ErrCli)
+}
+
+// scmForURL finds the scm hosting the repository along with the owner and
+// name of the repository on it. The scm whose uri most closely matches the
+// repository is chosen.
+func (c config) scmForURL(repoURL string) (string, string, string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", "", fmt.Errorf("could not parse repository url %s: %w", repoURL, err)
+	}
+
+	// Consider the scms in a stable order
+	names := make([]string, 0, len(c.Scms))
+	for name := range c.Scms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var host, repoPath string
+	longest := -1
+
+	for _, name := range names {
+		base, err := url.Parse(c.Scms[name].URI)
+		if err != nil || base.Host == "" || !strings.EqualFold(base.Host, u.Host) {
+			continue
+		}
+
+		basePath := strings.Trim(base.Path, "/")
+		rest := strings.Trim(u.Path, "/")
+		if basePath != "" {
+			if !strings.HasPrefix(rest, basePath+"/") {
+				continue
+			}
+			rest = rest[len(basePath)+1:]
+		}
+
+		if len(basePath) > longest {
+			host, repoPath, longest = name, rest, len(basePath)
+		}
+	}
+
+	if host == "" {
+		return "", "", "", fmt.Errorf("no scm configured for %s: %w", repoURL, ErrCli)
+	}
+
+	// Only a git remote needs the suffix
+	if c.Scms[host].Driver != reqcheck.DriverGit {
+		repoPath = strings.TrimSuffix(repoPath, ".git")
+	}
+
+	owner, repo := path.Split(repoPath)
+	if repo == "" {
+		return "", "", "", fmt.Errorf("no repository within %s: %w", repoURL, ErrCli)
+	}
+
+	return host, strings.TrimSuffix(owner, "/"), repo, nil
+}
+
+// portNames lists every port within the overlays and vcpkg repository, or
+// the registries of a project.
+func (v vcpkgRepository) portNames() ([]string, error) {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, dir := range v.portPaths() {
+		// An overlay may be the port itself
+		if _, err := os.Stat(filepath.Join(dir, "vcpkg.json")); err == nil {
+			if name := filepath.Base(dir); !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+
+			continue
+		}
+
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not list ports in %s: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() || seen[entry.Name()] {
+				continue
+			}
+			if _, err = os.Stat(filepath.Join(dir, entry.Name(), "vcpkg.json")); err != nil {
+				continue
+			}
+
+			seen[entry.Name()] = true
+			names = append(names, entry.Name())
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
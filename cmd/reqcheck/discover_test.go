@@ -0,0 +1,142 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/WebKitForWindows/reqcheck"
+)
+
+func TestReadPortUpstream(t *testing.T) {
+	tests := []struct {
+		portfile string
+		expected string
+	}{
+		{"vcpkg_from_github(OUT_SOURCE_PATH SOURCE_PATH REPO madler/zlib REF v1.3)", "https://github.com/madler/zlib"},
+		{"vcpkg_from_github(REPO webkit/${PORT} GITHUB_HOST https://github.example.com/)", "https://github.example.com/webkit/port"},
+		{"VCPKG_FROM_GITLAB(GITLAB_URL https://gitlab.gnome.org/ REPO /GNOME/libxml2/)", "https://gitlab.gnome.org/GNOME/libxml2"},
+		{"vcpkg_from_bitbucket(REPO owner/repo)", "https://bitbucket.org/owner/repo"},
+		{"vcpkg_from_git(URL https://git.example.com/${PORT}.git REF 0)", "https://git.example.com/port.git"},
+		// The first download is used
+		{"vcpkg_download_distfile(ARCHIVE URLS https://example.com/a.tar.gz)\nvcpkg_from_github(REPO a/b)\nvcpkg_from_github(REPO c/d)", "https://github.com/a/b"},
+		// Errors
+		{"vcpkg_from_github(REF v1.3)", ""},
+		{"vcpkg_from_gitlab(REPO GNOME/libxml2)", ""},
+		{"vcpkg_from_github(REPO ${OWNER}/zlib)", ""},
+		{"vcpkg_download_distfile(ARCHIVE URLS https://example.com/a.tar.gz)", ""},
+		{"vcpkg_from_github(REPO a/b", ""},
+	}
+
+	for i, test := range tests {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"portfile.cmake": test.portfile})
+
+		repoURL, err := readPortUpstream(dir, "port")
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("%d: expected an error, got %s", i, repoURL)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%d: %v", i, err)
+		} else if repoURL != test.expected {
+			t.Errorf("%d: expected %s, got %s", i, test.expected, repoURL)
+		}
+	}
+
+	if _, err := readPortUpstream(filepath.Join(t.TempDir(), "missing"), "port"); err == nil {
+		t.Error("expected an error for a missing portfile")
+	}
+}
+
+func TestScmForURL(t *testing.T) {
+	c := config{Scms: map[string]sourceControl{
+		"github":     {Driver: reqcheck.DriverGitHub, URI: "https://github.com"},
+		"enterprise": {Driver: reqcheck.DriverGitHub, URI: "https://github.example.com/"},
+		"gitlab":     {Driver: reqcheck.DriverGitLab, URI: "https://gitlab.example.com/"},
+		"subgroup":   {Driver: reqcheck.DriverGitLab, URI: "https://gitlab.example.com/mirrors/"},
+		"git":        {Driver: reqcheck.DriverGit, URI: "https://git.example.com"},
+		"invalid":    {Driver: reqcheck.DriverGit, URI: "://"},
+	}}
+
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://github.com/madler/zlib", "github madler zlib"},
+		{"https://GitHub.com/madler/zlib.git", "github madler zlib"},
+		{"https://github.example.com/webkit/port", "enterprise webkit port"},
+		{"https://gitlab.example.com/GNOME/libxml2", "gitlab GNOME libxml2"},
+		{"https://gitlab.example.com/group/subgroup/project", "gitlab group/subgroup project"},
+		// The most specific uri is chosen
+		{"https://gitlab.example.com/mirrors/curl/curl", "subgroup curl curl"},
+		{"https://gitlab.example.com/mirrorsx/curl", "gitlab mirrorsx curl"},
+		// Only a git remote keeps the suffix
+		{"https://git.example.com/libpng.git", "git  libpng.git"},
+		// Errors
+		{"https://bitbucket.org/owner/repo", ""},
+		{"https://github.com/", ""},
+		{"://github.com", ""},
+	}
+
+	for _, test := range tests {
+		host, owner, repo, err := c.scmForURL(test.url)
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %s %s %s", test.url, host, owner, repo)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: %v", test.url, err)
+		} else if actual := fmt.Sprintf("%s %s %s", host, owner, repo); actual != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.url, test.expected, actual)
+		}
+	}
+}
+
+func TestPortNames(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"overlays/ports/zlib/vcpkg.json":        `{"name": "zlib"}`,
+		"overlays/ports/empty/README.md":        "not a port",
+		"ports/curl/vcpkg.json":                 `{"name": "curl"}`,
+		"ports/zlib/vcpkg.json":                 `{"name": "zlib"}`,
+		"project/overlay/libpng/vcpkg.json":     `{"name": "libpng"}`,
+		"project/registry/ports/fmt/vcpkg.json": `{"name": "fmt"}`,
+	})
+
+	v := vcpkgRepository{Path: dir, Overlays: []string{filepath.Join(dir, "overlays"), filepath.Join(dir, "missing")}}
+
+	names, err := v.portNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(names) != "[curl zlib]" {
+		t.Errorf("unexpected ports %v", names)
+	}
+
+	// An overlay of a project may be the port itself
+	v.Manifest = &projectManifest{PortPaths: []string{
+		filepath.Join(dir, "project", "overlay", "libpng"),
+		filepath.Join(dir, "project", "registry", "ports"),
+	}}
+
+	names, err = v.portNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(names) != "[fmt libpng zlib]" {
+		t.Errorf("unexpected ports %v", names)
+	}
+}
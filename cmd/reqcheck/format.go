@@ -0,0 +1,314 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WebKitForWindows/reqcheck"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatJSONL = "jsonl"
+	formatYAML  = "yaml"
+	formatCSV   = "csv"
+)
+
+var formats = []string{formatText, formatJSON, formatJSONL, formatYAML, formatCSV}
+
+// Status of a library within the results of the vcpkg command
+const (
+	statusCurrent = "current"
+	statusUpgrade = "upgrade"
+	statusUpdated = "updated"
+	statusError   = "error"
+)
+
+type (
+	// result is a single entry of the results written by a command.
+	result interface {
+		csvHeader() []string
+		csvRecord() []string
+	}
+
+	// releaseResult is a release found by a query command.
+	releaseResult struct {
+		Tag         string     `json:"tag" yaml:"tag"`
+		Version     string     `json:"version" yaml:"version"`
+		Scheme      string     `json:"scheme" yaml:"scheme"`
+		Commit      string     `json:"commit" yaml:"commit"`
+		URL         string     `json:"url" yaml:"url"`
+		PublishedAt *time.Time `json:"published_at,omitempty" yaml:"published_at,omitempty"`
+		Draft       bool       `json:"draft" yaml:"draft"`
+		Prerelease  bool       `json:"prerelease" yaml:"prerelease"`
+	}
+
+	// libraryResult is a library checked by the vcpkg command. The status is
+	// current, upgrade or error. The release fields are empty for an error.
+	libraryResult struct {
+		Name        string     `json:"name" yaml:"name"`
+		Status      string     `json:"status" yaml:"status"`
+		Host        string     `json:"host" yaml:"host"`
+		Owner       string     `json:"owner" yaml:"owner"`
+		Repo        string     `json:"repo" yaml:"repo"`
+		Current     string     `json:"current" yaml:"current"`
+		PortVersion int        `json:"port_version" yaml:"port_version"`
+		Baseline    string     `json:"baseline" yaml:"baseline"`
+		Mismatch    bool       `json:"baseline_mismatch" yaml:"baseline_mismatch"`
+		Latest      string     `json:"latest" yaml:"latest"`
+		Tag         string     `json:"tag" yaml:"tag"`
+		Commit      string     `json:"commit" yaml:"commit"`
+		URL         string     `json:"url" yaml:"url"`
+		PublishedAt *time.Time `json:"published_at,omitempty" yaml:"published_at,omitempty"`
+		Draft       bool       `json:"draft" yaml:"draft"`
+		Prerelease  bool       `json:"prerelease" yaml:"prerelease"`
+		Dependents  []string   `json:"dependents,omitempty" yaml:"dependents,omitempty"`
+		Error       string     `json:"error,omitempty" yaml:"error,omitempty"`
+	}
+
+	// updateResult is a library updated by the vcpkg update command. The
+	// status is updated, upgrade when performing a dry run, or error. A dry
+	// run includes the diff of the changes.
+	updateResult struct {
+		Name    string   `json:"name" yaml:"name"`
+		Status  string   `json:"status" yaml:"status"`
+		Current string   `json:"current" yaml:"current"`
+		Latest  string   `json:"latest" yaml:"latest"`
+		Files   []string `json:"files,omitempty" yaml:"files,omitempty"`
+		Diff    string   `json:"diff,omitempty" yaml:"diff,omitempty"`
+		Error   string   `json:"error,omitempty" yaml:"error,omitempty"`
+	}
+
+	// cacheStatsResult is the contents of the cache.
+	cacheStatsResult struct {
+		Directory string     `json:"directory" yaml:"directory"`
+		Entries   int        `json:"entries" yaml:"entries"`
+		Size      int64      `json:"size" yaml:"size"`
+		Oldest    *time.Time `json:"oldest,omitempty" yaml:"oldest,omitempty"`
+		Newest    *time.Time `json:"newest,omitempty" yaml:"newest,omitempty"`
+	}
+)
+
+func checkFormat(format string) error {
+	if !slices.Contains(formats, format) {
+		return fmt.Errorf("unknown output format %s: %w", format, ErrCli)
+	}
+
+	return nil
+}
+
+// writeResults serialises the results in a format other than text.
+func writeResults[T result](w io.Writer, format string, results []T) error {
+	// Always write a list even when empty
+	if results == nil {
+		results = []T{}
+	}
+
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(results)
+	case formatJSONL:
+		enc := json.NewEncoder(w)
+		for _, r := range results {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case formatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+
+		return enc.Close()
+	case formatCSV:
+		cw := csv.NewWriter(w)
+
+		var header T
+		if err := cw.Write(header.csvHeader()); err != nil {
+			return err
+		}
+		for _, r := range results {
+			if err := cw.Write(r.csvRecord()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+
+		return cw.Error()
+	}
+
+	return fmt.Errorf("unknown output format %s: %w", format, ErrCli)
+}
+
+func newReleaseResult(release reqcheck.Release, scheme reqcheck.Scheme) releaseResult {
+	r := releaseResult{
+		Tag:         release.Tag,
+		Scheme:      scheme.Name(),
+		Commit:      release.Commit,
+		URL:         release.URL,
+		PublishedAt: timeOrNil(release.PublishedAt),
+		Draft:       release.Draft,
+		Prerelease:  release.Prerelease,
+	}
+	if release.Version != nil {
+		r.Version = release.Version.String()
+	}
+
+	return r
+}
+
+func (releaseResult) csvHeader() []string {
+	return []string{"tag", "version", "scheme", "commit", "url", "published_at", "draft", "prerelease"}
+}
+
+func (r releaseResult) csvRecord() []string {
+	return []string{
+		r.Tag,
+		r.Version,
+		r.Scheme,
+		r.Commit,
+		r.URL,
+		csvTime(r.PublishedAt),
+		strconv.FormatBool(r.Draft),
+		strconv.FormatBool(r.Prerelease),
+	}
+}
+
+func newLibraryResult(status string, release releaseUpdate) libraryResult {
+	return libraryResult{
+		Name:        release.Name,
+		Status:      status,
+		Host:        release.Host,
+		Owner:       release.Owner,
+		Repo:        release.Repo,
+		Current:     release.Current,
+		PortVersion: release.PortVersion,
+		Baseline:    release.Baseline,
+		Mismatch:    release.BaselineMismatch,
+		Latest:      release.Upgrade,
+		Tag:         release.Tag,
+		Commit:      release.Commit,
+		URL:         release.URL,
+		PublishedAt: timeOrNil(release.PublishedAt),
+		Draft:       release.Draft,
+		Prerelease:  release.Prerelease,
+		Dependents:  release.Dependents,
+	}
+}
+
+func newLibraryErrorResult(failure releaseError) libraryResult {
+	return libraryResult{
+		Name:   failure.Name,
+		Status: statusError,
+		Host:   failure.Host,
+		Owner:  failure.Owner,
+		Repo:   failure.Repo,
+		Error:  failure.Reason,
+	}
+}
+
+func (libraryResult) csvHeader() []string {
+	return []string{
+		"name", "status", "host", "owner", "repo", "current", "port_version", "baseline",
+		"baseline_mismatch", "latest", "tag", "commit", "url", "published_at", "draft",
+		"prerelease", "dependents", "error",
+	}
+}
+
+func (r libraryResult) csvRecord() []string {
+	return []string{
+		r.Name,
+		r.Status,
+		r.Host,
+		r.Owner,
+		r.Repo,
+		r.Current,
+		strconv.Itoa(r.PortVersion),
+		r.Baseline,
+		strconv.FormatBool(r.Mismatch),
+		r.Latest,
+		r.Tag,
+		r.Commit,
+		r.URL,
+		csvTime(r.PublishedAt),
+		strconv.FormatBool(r.Draft),
+		strconv.FormatBool(r.Prerelease),
+		strings.Join(r.Dependents, " "),
+		r.Error,
+	}
+}
+
+func (updateResult) csvHeader() []string {
+	return []string{"name", "status", "current", "latest", "files", "diff", "error"}
+}
+
+func (r updateResult) csvRecord() []string {
+	return []string{
+		r.Name,
+		r.Status,
+		r.Current,
+		r.Latest,
+		strings.Join(r.Files, " "),
+		r.Diff,
+		r.Error,
+	}
+}
+
+func newCacheStatsResult(cache *reqcheck.Cache, stats reqcheck.CacheStats) cacheStatsResult {
+	return cacheStatsResult{
+		Directory: cache.Dir,
+		Entries:   stats.Entries,
+		Size:      stats.Size,
+		Oldest:    timeOrNil(stats.Oldest),
+		Newest:    timeOrNil(stats.Newest),
+	}
+}
+
+func (cacheStatsResult) csvHeader() []string {
+	return []string{"directory", "entries", "size", "oldest", "newest"}
+}
+
+func (r cacheStatsResult) csvRecord() []string {
+	return []string{
+		r.Directory,
+		strconv.Itoa(r.Entries),
+		strconv.FormatInt(r.Size, 10),
+		csvTime(r.Oldest),
+		csvTime(r.Newest),
+	}
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/WebKitForWindows/reqcheck"
+	"github.com/urfave/cli/v3"
+)
+
+func giteaCmd() *cli.Command {
+	var settings querySettings
+
+	return &cli.Command{
+		Name:      "gitea",
+		Usage:     "query gitea or forgejo for requirements",
+		ArgsUsage: "<owner> <repo>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "uri",
+				Usage:       "uri for gitea or forgejo instance",
+				Value:       "https://gitea.com",
+				Destination: &settings.URI,
+			},
+			&cli.StringFlag{
+				Name:        "token",
+				Usage:       "access token for gitea api",
+				Sources:     cli.EnvVars("GITEA_TOKEN"),
+				Destination: &settings.Token,
+			},
+			&cli.BoolFlag{
+				Name:        "tags",
+				Usage:       "use tags rather than releases",
+				Destination: &settings.Tags,
+			},
+			&cli.BoolFlag{
+				Name:        "prerelease",
+				Usage:       "include pre-releases",
+				Destination: &settings.Prerelease,
+			},
+			&cli.BoolFlag{
+				Name:        "draft",
+				Usage:       "include draft releases",
+				Destination: &settings.Draft,
+			},
+			&cli.StringFlag{
+				Name:        "scheme",
+				Usage:       "version scheme (semver, calver, date, dotted or openssl)",
+				Value:       reqcheck.SchemeSemVer,
+				Destination: &settings.Scheme,
+			},
+			&cli.StringFlag{
+				Name:        "constraint",
+				Usage:       "version constraint",
+				Destination: &settings.Constraint,
+			},
+			&cli.StringFlag{
+				Name:        "pattern",
+				Usage:       "regular expression extracting the version from a tag",
+				Destination: &settings.Pattern,
+			},
+			&cli.StringFlag{
+				Name:        "strip-prefix",
+				Usage:       "prefix to remove from a tag before extracting the version",
+				Destination: &settings.Prefix,
+			},
+			&cli.StringFlag{
+				Name:        "strip-suffix",
+				Usage:       "suffix to remove from a tag before extracting the version",
+				Destination: &settings.Suffix,
+			},
+			&cli.StringSliceFlag{
+				Name:        "exclude",
+				Usage:       "regular expression for tags to ignore",
+				Destination: &settings.Exclude,
+			},
+			&cli.IntFlag{
+				Name:        "limit-to",
+				Usage:       "limit the amount of results from the api",
+				Destination: &settings.LimitTo,
+			},
+		},
+		Action: queryAction(reqcheck.DriverGitea, &settings),
+	}
+}
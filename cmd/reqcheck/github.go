@@ -34,16 +34,52 @@ func githubCmd() *cli.Command {
 				Usage:       "use tags rather than releases",
 				Destination: &settings.Tags,
 			},
+			&cli.BoolFlag{
+				Name:        "graphql",
+				Usage:       "use the graphql api rather than the rest api",
+				Destination: &settings.GraphQL,
+			},
 			&cli.BoolFlag{
 				Name:        "prerelease",
 				Usage:       "include pre-releases",
 				Destination: &settings.Prerelease,
 			},
+			&cli.BoolFlag{
+				Name:        "draft",
+				Usage:       "include draft releases",
+				Destination: &settings.Draft,
+			},
+			&cli.StringFlag{
+				Name:        "scheme",
+				Usage:       "version scheme (semver, calver, date, dotted or openssl)",
+				Value:       reqcheck.SchemeSemVer,
+				Destination: &settings.Scheme,
+			},
 			&cli.StringFlag{
 				Name:        "constraint",
-				Usage:       "semantic version constraint",
+				Usage:       "version constraint",
 				Destination: &settings.Constraint,
 			},
+			&cli.StringFlag{
+				Name:        "pattern",
+				Usage:       "regular expression extracting the version from a tag",
+				Destination: &settings.Pattern,
+			},
+			&cli.StringFlag{
+				Name:        "strip-prefix",
+				Usage:       "prefix to remove from a tag before extracting the version",
+				Destination: &settings.Prefix,
+			},
+			&cli.StringFlag{
+				Name:        "strip-suffix",
+				Usage:       "suffix to remove from a tag before extracting the version",
+				Destination: &settings.Suffix,
+			},
+			&cli.StringSliceFlag{
+				Name:        "exclude",
+				Usage:       "regular expression for tags to ignore",
+				Destination: &settings.Exclude,
+			},
 			&cli.IntFlag{
 				Name:        "limit-to",
 				Usage:       "limit the amount of results from the api",
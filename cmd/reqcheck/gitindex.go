@@ -0,0 +1,403 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+type (
+	// gitRepository is the location of a git repository on disk.
+	gitRepository struct {
+		// WorkTree is the directory checked out.
+		WorkTree string
+		// GitDir holds the index of the work tree.
+		GitDir string
+		// CommonDir holds the config, which differs from the GitDir for a
+		// linked work tree.
+		CommonDir string
+	}
+
+	// gitIndexEntry is a file staged within the index.
+	gitIndexEntry struct {
+		Mode  uint32
+		Hash  []byte
+		Stage int
+		Path  string
+	}
+
+	// gitAttribute is a line of a .gitattributes file.
+	gitAttribute struct {
+		Dir     string
+		Pattern string
+		Text    string
+	}
+)
+
+const (
+	gitIndexEntrySize    = 62
+	gitIndexExtended     = 0x4000
+	gitIndexIntentToAdd  = 0x2000
+	gitIndexStageShift   = 12
+	gitModeDirectory     = 0o040000
+	gitSplitIndexSection = "link"
+)
+
+// findGitRepository finds the repository whose work tree contains the
+// directory. False is returned when the directory is not within a work tree.
+func findGitRepository(dir string) (gitRepository, bool, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return gitRepository{}, false, err
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	for {
+		dotGit := filepath.Join(dir, ".git")
+
+		info, err := os.Stat(dotGit)
+		switch {
+		case err == nil && info.IsDir():
+			return gitRepository{WorkTree: dir, GitDir: dotGit, CommonDir: dotGit}, true, nil
+		case err == nil:
+			repo, err := readGitFile(dir, dotGit)
+			return repo, err == nil, err
+		case !errors.Is(err, os.ErrNotExist):
+			return gitRepository{}, false, err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return gitRepository{}, false, nil
+		}
+		dir = parent
+	}
+}
+
+// readGitFile reads the .git file of a linked work tree or submodule, which
+// points at the actual git directory.
+func readGitFile(workTree, dotGit string) (gitRepository, error) {
+	b, err := os.ReadFile(dotGit)
+	if err != nil {
+		return gitRepository{}, err
+	}
+
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "gitdir:")
+	if !ok {
+		return gitRepository{}, fmt.Errorf("unexpected contents of %s: %w", dotGit, ErrCli)
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(workTree, gitDir)
+	}
+
+	// A linked work tree shares the config of the main repository
+	commonDir := gitDir
+	if b, err = os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = strings.TrimSpace(string(b))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+	}
+
+	return gitRepository{WorkTree: workTree, GitDir: gitDir, CommonDir: commonDir}, nil
+}
+
+// readIndex reads the entries of the index. Versions 2 to 4 of the index
+// format are supported, but not a split index.
+func (r gitRepository) readIndex() ([]gitIndexEntry, error) {
+	data, err := os.ReadFile(filepath.Join(r.GitDir, "index"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The index ends with its checksum
+	if len(data) < 12+20 || string(data[:4]) != "DIRC" {
+		return nil, fmt.Errorf("%s is not a git index: %w", r.GitDir, ErrCli)
+	}
+	data = data[:len(data)-20]
+
+	version := binary.BigEndian.Uint32(data[4:8])
+	if version < 2 || version > 4 {
+		return nil, fmt.Errorf("unsupported git index version %d: %w", version, ErrCli)
+	}
+
+	count := binary.BigEndian.Uint32(data[8:12])
+	entries := make([]gitIndexEntry, 0, count)
+
+	offset := 12
+	previous := ""
+	for range count {
+		if offset+gitIndexEntrySize > len(data) {
+			return nil, fmt.Errorf("git index is truncated: %w", ErrCli)
+		}
+
+		start := offset
+		mode := binary.BigEndian.Uint32(data[offset+24 : offset+28])
+		hash := data[offset+40 : offset+60]
+		flags := binary.BigEndian.Uint16(data[offset+60 : offset+62])
+		offset += gitIndexEntrySize
+
+		var extended uint16
+		if flags&gitIndexExtended != 0 {
+			if offset+2 > len(data) {
+				return nil, fmt.Errorf("git index is truncated: %w", ErrCli)
+			}
+
+			extended = binary.BigEndian.Uint16(data[offset : offset+2])
+			offset += 2
+		}
+
+		// Version 4 removes the end of the previous path before the name
+		var name string
+		if version == 4 {
+			strip, n := readGitVarint(data[offset:])
+			if n == 0 || strip > uint64(len(previous)) {
+				return nil, fmt.Errorf("git index is corrupt: %w", ErrCli)
+			}
+			offset += n
+
+			end := bytes.IndexByte(data[offset:], 0)
+			if end < 0 {
+				return nil, fmt.Errorf("git index is truncated: %w", ErrCli)
+			}
+
+			name = previous[:len(previous)-int(strip)] + string(data[offset:offset+end])
+			offset += end + 1
+		} else {
+			end := bytes.IndexByte(data[offset:], 0)
+			if end < 0 {
+				return nil, fmt.Errorf("git index is truncated: %w", ErrCli)
+			}
+
+			name = string(data[offset : offset+end])
+
+			// Entries are padded with NULs to a multiple of eight bytes
+			offset = start + (offset+end-start+8)&^7
+		}
+		previous = name
+
+		// A file added with --intent-to-add is not part of the tree
+		if extended&gitIndexIntentToAdd != 0 {
+			continue
+		}
+
+		entries = append(entries, gitIndexEntry{
+			Mode:  mode,
+			Hash:  bytes.Clone(hash),
+			Stage: int(flags>>gitIndexStageShift) & 3,
+			Path:  name,
+		})
+	}
+
+	for offset+8 <= len(data) {
+		if string(data[offset:offset+4]) == gitSplitIndexSection {
+			return nil, fmt.Errorf("a split git index is not supported: %w", ErrCli)
+		}
+
+		offset += 8 + int(binary.BigEndian.Uint32(data[offset+4:offset+8]))
+	}
+
+	return entries, nil
+}
+
+// readGitVarint reads the variable length integer git uses for offsets,
+// returning the number of bytes read which is zero when it is invalid.
+func readGitVarint(b []byte) (uint64, int) {
+	if len(b) == 0 {
+		return 0, 0
+	}
+
+	val := uint64(b[0] & 0x7f)
+	n := 1
+	for b[n-1]&0x80 != 0 {
+		if n >= len(b) || n > 9 {
+			return 0, 0
+		}
+
+		val = ((val + 1) << 7) | uint64(b[n]&0x7f)
+		n++
+	}
+
+	return val, n
+}
+
+// configValue reads the value of a setting, such as core.autocrlf, from the
+// system, global and repository config in order of precedence. Includes are
+// not followed.
+func (r gitRepository) configValue(section, key string) string {
+	var files []string
+	if os.Getenv("GIT_CONFIG_NOSYSTEM") == "" {
+		if system := os.Getenv("GIT_CONFIG_SYSTEM"); system != "" {
+			files = append(files, system)
+		} else if runtime.GOOS == "windows" {
+			files = append(files, filepath.Join(os.Getenv("ProgramFiles"), "Git", "etc", "gitconfig"))
+		} else {
+			files = append(files, "/etc/gitconfig")
+		}
+	}
+	if global := os.Getenv("GIT_CONFIG_GLOBAL"); global != "" {
+		files = append(files, global)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+
+		files = append(files, filepath.Join(configHome, "git", "config"), filepath.Join(home, ".gitconfig"))
+	}
+	files = append(files, filepath.Join(r.CommonDir, "config"))
+
+	value := ""
+	for _, file := range files {
+		if val, ok := readGitConfig(file, section, key); ok {
+			value = val
+		}
+	}
+
+	return value
+}
+
+// readGitConfig reads the last value of the setting within the config file.
+func readGitConfig(file, section, key string) (string, bool) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	var current, value string
+	found := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			current = strings.ToLower(strings.Trim(line, "[] \t"))
+			continue
+		}
+
+		name, val, hasValue := strings.Cut(line, "=")
+		if current != section || !strings.EqualFold(strings.TrimSpace(name), key) {
+			continue
+		}
+
+		// A setting without a value is true
+		value, found = "true", true
+		if hasValue {
+			value = strings.Trim(strings.TrimSpace(val), `"`)
+		}
+	}
+
+	return value, found
+}
+
+// textAttribute determines how the line endings of the file are treated from
+// the .gitattributes files of the work tree. The result is set for text,
+// unset for binary, auto or empty when unspecified.
+func (r gitRepository) textAttribute(rel string) string {
+	// Attributes are read from the root down to the directory of the file
+	dirs := []string{""}
+	if dir := path.Dir(rel); dir != "." {
+		parts := strings.Split(dir, "/")
+		for i := range parts {
+			dirs = append(dirs, strings.Join(parts[:i+1], "/"))
+		}
+	}
+
+	var attributes []gitAttribute
+	for _, dir := range dirs {
+		attributes = append(attributes, readGitAttributes(filepath.Join(r.WorkTree, filepath.FromSlash(dir), ".gitattributes"), dir)...)
+	}
+
+	// The last matching line takes precedence
+	text := ""
+	for _, attribute := range attributes {
+		name := strings.TrimPrefix(strings.TrimPrefix(rel, attribute.Dir), "/")
+
+		pattern := strings.TrimPrefix(attribute.Pattern, "/")
+		if !strings.Contains(attribute.Pattern, "/") {
+			name = path.Base(rel)
+		}
+
+		if matched, _ := path.Match(pattern, name); matched {
+			text = attribute.Text
+		}
+	}
+
+	return text
+}
+
+// readGitAttributes reads the lines of a .gitattributes file that affect the
+// line endings of files.
+func readGitAttributes(file, dir string) []gitAttribute {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+
+	var attributes []gitAttribute
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		text := ""
+		for _, field := range fields[1:] {
+			switch {
+			case field == "text" || strings.HasPrefix(field, "eol="):
+				text = "set"
+			case field == "-text" || field == "binary":
+				text = "unset"
+			case field == "text=auto":
+				text = "auto"
+			}
+		}
+
+		if text != "" {
+			attributes = append(attributes, gitAttribute{Dir: dir, Pattern: fields[0], Text: text})
+		}
+	}
+
+	return attributes
+}
+
+// convertToGit applies the line ending conversion git performs when the file
+// is staged.
+func (r gitRepository) convertToGit(rel string, content []byte) []byte {
+	text := r.textAttribute(rel)
+	if text == "" {
+		switch strings.ToLower(r.configValue("core", "autocrlf")) {
+		case "true", "input", "yes", "on", "1":
+			text = "auto"
+		}
+	}
+
+	// Binary files are left as is
+	if text == "" || text == "unset" || (text == "auto" && bytes.Contains(content, []byte{0})) {
+		return content
+	}
+
+	return bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+}
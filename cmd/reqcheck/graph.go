@@ -0,0 +1,254 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	graphDOT     = "dot"
+	graphMermaid = "mermaid"
+)
+
+var graphFormats = []string{graphDOT, graphMermaid}
+
+// Colors of the ports in the graph for each state
+const (
+	graphColorUpgrade = "#f1c40f"
+	graphColorError   = "#e74c3c"
+	graphColorCurrent = "#2ecc71"
+)
+
+// dependencyGraph is the dependencies of each port.
+type dependencyGraph map[string][]string
+
+func checkGraphFormat(format string) error {
+	if format != "" && !slices.Contains(graphFormats, format) {
+		return fmt.Errorf("unknown graph format %s: %w", format, ErrCli)
+	}
+
+	return nil
+}
+
+// readDependencyGraph reads the dependencies of the ports and of every port
+// they depend on. A port which cannot be found or whose manifest cannot be
+// read is left out of the graph.
+func (v vcpkgRepository) readDependencyGraph(names []string) dependencyGraph {
+	g := make(dependencyGraph, len(names))
+	seen := make(map[string]bool, len(names))
+
+	pending := slices.Clone(names)
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		_, manifest, err := v.findPort(name)
+		if err != nil {
+			logrus.WithField("port", name).Debug("could not find port for dependencies")
+			continue
+		}
+
+		var m struct {
+			Dependencies []manifestDependency `json:"dependencies"`
+		}
+		if err = json.Unmarshal(manifest, &m); err != nil {
+			logrus.WithError(err).WithField("port", name).Warn("could not read dependencies")
+			continue
+		}
+
+		var dependencies []string
+		for _, dependency := range m.Dependencies {
+			if !slices.Contains(dependencies, dependency.Name) {
+				dependencies = append(dependencies, dependency.Name)
+			}
+		}
+		sort.Strings(dependencies)
+
+		g[name] = dependencies
+		pending = append(pending, dependencies...)
+	}
+
+	return g
+}
+
+// dependents lists the ports depending directly on the port.
+func (g dependencyGraph) dependents(name string) []string {
+	var dependents []string
+	for port, dependencies := range g {
+		if slices.Contains(dependencies, name) {
+			dependents = append(dependents, port)
+		}
+	}
+	sort.Strings(dependents)
+
+	return dependents
+}
+
+// depths determines how far each port is from a port without dependencies.
+// Ordering by depth places every port after its dependencies. Ports are
+// visited by name so a cycle is always broken at the same port.
+func (g dependencyGraph) depths() map[string]int {
+	depths := make(map[string]int, len(g))
+	visiting := make(map[string]bool)
+
+	var visit func(name string) int
+	visit = func(name string) int {
+		if depth, ok := depths[name]; ok {
+			return depth
+		}
+		if visiting[name] {
+			return 0
+		}
+		visiting[name] = true
+
+		depth := 0
+		for _, dependency := range g[name] {
+			depth = max(depth, visit(dependency)+1)
+		}
+
+		visiting[name] = false
+		depths[name] = depth
+
+		return depth
+	}
+
+	names := make([]string, 0, len(g))
+	for name := range g {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		visit(name)
+	}
+
+	return depths
+}
+
+// orderByDependencies orders the upgrades so each follows the upgrades of
+// its dependencies. The ports depending on each upgrade are listed from all,
+// which holds every port rather than only those read for the graph.
+func (r *report) orderByDependencies(g, all dependencyGraph) {
+	depths := g.depths()
+
+	sort.SliceStable(r.Upgrade, func(i, j int) bool {
+		return depths[r.Upgrade[i].Name] < depths[r.Upgrade[j].Name]
+	})
+
+	for i := range r.Upgrade {
+		r.Upgrade[i].Dependents = all.dependents(r.Upgrade[i].Name)
+	}
+
+	r.graph = g
+}
+
+// writeGraph outputs the dependency graph with the ports colored by the
+// results of checking them.
+func writeGraph(w io.Writer, format string, r report) error {
+	// Include dependencies on ports which are not found
+	nodes := make(map[string]bool)
+	for name, dependencies := range r.graph {
+		nodes[name] = true
+		for _, dependency := range dependencies {
+			nodes[dependency] = true
+		}
+	}
+	for _, release := range append(append([]releaseUpdate{}, r.Current...), r.Upgrade...) {
+		nodes[release.Name] = true
+	}
+	for _, failure := range r.Errors {
+		nodes[failure.Name] = true
+	}
+
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Describe the state of each port checked
+	labels := make(map[string]string)
+	colors := make(map[string]string)
+	for _, release := range r.Current {
+		labels[release.Name] = release.currentString()
+		colors[release.Name] = graphColorCurrent
+	}
+	for _, release := range r.Upgrade {
+		labels[release.Name] = release.currentString() + " -> " + release.Upgrade
+		colors[release.Name] = graphColorUpgrade
+	}
+	for _, failure := range r.Errors {
+		colors[failure.Name] = graphColorError
+	}
+
+	var sb strings.Builder
+
+	switch format {
+	case graphDOT:
+		sb.WriteString("digraph vcpkg {\n  node [shape=box];\n")
+		for _, name := range names {
+			label := name
+			if labels[name] != "" {
+				label += "\n" + labels[name]
+			}
+
+			fmt.Fprintf(&sb, "  %s [label=%s", strconv.Quote(name), strconv.Quote(label))
+			if colors[name] != "" {
+				fmt.Fprintf(&sb, ", style=filled, fillcolor=%s", strconv.Quote(colors[name]))
+			}
+			sb.WriteString("];\n")
+		}
+		for _, name := range names {
+			for _, dependency := range r.graph[name] {
+				fmt.Fprintf(&sb, "  %s -> %s;\n", strconv.Quote(name), strconv.Quote(dependency))
+			}
+		}
+		sb.WriteString("}\n")
+	case graphMermaid:
+		// Port names are not always valid identifiers
+		ids := make(map[string]string, len(names))
+		for i, name := range names {
+			ids[name] = fmt.Sprintf("n%d", i)
+		}
+
+		sb.WriteString("graph TD\n")
+		for _, name := range names {
+			label := name
+			if labels[name] != "" {
+				label += "<br/>" + labels[name]
+			}
+
+			fmt.Fprintf(&sb, "  %s[\"%s\"]\n", ids[name], strings.ReplaceAll(label, `"`, "#quot;"))
+			if colors[name] != "" {
+				fmt.Fprintf(&sb, "  style %s fill:%s\n", ids[name], colors[name])
+			}
+		}
+		for _, name := range names {
+			for _, dependency := range r.graph[name] {
+				fmt.Fprintf(&sb, "  %s --> %s\n", ids[name], ids[dependency])
+			}
+		}
+	default:
+		return fmt.Errorf("unknown graph format %s: %w", format, ErrCli)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
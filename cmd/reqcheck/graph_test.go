@@ -0,0 +1,114 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestReadDependencyGraph(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"ports/curl/vcpkg.json":    `{"name": "curl", "dependencies": ["zlib", {"name": "openssl"}, "zlib"]}`,
+		"ports/openssl/vcpkg.json": `{"name": "openssl"}`,
+		"ports/zlib/vcpkg.json":    `{"name": "zlib"}`,
+		"ports/libpng/vcpkg.json":  `{"name": "libpng", "dependencies": ["zlib"]}`,
+		"ports/broken/vcpkg.json":  `{"name": "broken", "dependencies": 1}`,
+	})
+
+	v := vcpkgRepository{Path: dir}
+
+	// Only the ports checked and their dependencies are read
+	g := v.readDependencyGraph([]string{"curl", "broken", "missing"})
+	if fmt.Sprint(g) != "map[curl:[openssl zlib] openssl:[] zlib:[]]" {
+		t.Errorf("unexpected graph %v", g)
+	}
+
+	// Every port is read for the dependents
+	ports, err := v.portNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := v.readDependencyGraph(ports)
+	if fmt.Sprint(all.dependents("zlib")) != "[curl libpng]" {
+		t.Errorf("unexpected dependents %v", all.dependents("zlib"))
+	}
+}
+
+func TestDepthsCycle(t *testing.T) {
+	g := dependencyGraph{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+		"d": {"c"},
+	}
+
+	// The cycle is broken at the first port by name on every run
+	for i := 0; i < 20; i++ {
+		depths := g.depths()
+		if fmt.Sprint(depths) != "map[a:3 b:2 c:1 d:2]" {
+			t.Fatalf("unexpected depths %v", depths)
+		}
+	}
+}
+
+func TestOrderByDependencies(t *testing.T) {
+	g := dependencyGraph{
+		"curl":    {"openssl", "zlib"},
+		"libpng":  {"zlib"},
+		"openssl": nil,
+		"zlib":    nil,
+	}
+
+	// Ports which were not checked are listed as dependents
+	all := dependencyGraph{"cairo": {"libpng", "zlib"}}
+	for name, dependencies := range g {
+		all[name] = dependencies
+	}
+
+	r := report{Upgrade: []releaseUpdate{{Name: "curl"}, {Name: "libpng"}, {Name: "openssl"}, {Name: "zlib"}}}
+	r.orderByDependencies(g, all)
+
+	var order []string
+	for _, release := range r.Upgrade {
+		order = append(order, release.Name+":"+strings.Join(release.Dependents, ","))
+	}
+	if fmt.Sprint(order) != "[openssl:curl zlib:cairo,curl,libpng curl: libpng:cairo]" {
+		t.Errorf("unexpected order %v", order)
+	}
+}
+
+func TestWriteGraph(t *testing.T) {
+	r := report{
+		Current: []releaseUpdate{{Name: "zlib", Current: "1.3"}},
+		Upgrade: []releaseUpdate{{Name: "curl", Current: "8.9.0", Upgrade: "8.10.0"}},
+		graph:   dependencyGraph{"curl": {"openssl", "zlib"}, "zlib": nil},
+	}
+
+	var sb strings.Builder
+	if err := writeGraph(&sb, graphMermaid, r); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `graph TD
+  n0["curl<br/>8.9.0 -> 8.10.0"]
+  style n0 fill:#f1c40f
+  n1["openssl"]
+  n2["zlib<br/>1.3"]
+  style n2 fill:#2ecc71
+  n0 --> n1
+  n0 --> n2
+`
+	if sb.String() != expected {
+		t.Errorf("unexpected graph\n%s", sb.String())
+	}
+
+	if err := writeGraph(&sb, "svg", r); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -21,6 +21,7 @@ var (
 
 func main() {
 	var logLevel string
+	var format string
 
 	app := &cli.Command{
 		Name:                  "reqcheck",
@@ -34,11 +35,28 @@ func main() {
 				Value:       "warning",
 				Destination: &logLevel,
 			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "output format (text, json, jsonl, yaml or csv)",
+				Value:       formatText,
+				Destination: &format,
+			},
+			&cli.StringFlag{
+				Name:    "cache-dir",
+				Usage:   "directory to cache responses in",
+				Sources: cli.EnvVars("REQCHECK_CACHE_DIR"),
+			},
+			&cli.DurationFlag{
+				Name:  "cache-ttl",
+				Usage: "how long to use a cached response before revalidating it",
+			},
 		},
 		Commands: []*cli.Command{
 			githubCmd(),
 			gitlabCmd(),
+			giteaCmd(),
 			vcpkgCmd(),
+			cacheCmd(),
 		},
 		Before: func(c context.Context, cmd *cli.Command) (context.Context, error) {
 			lvl, err := logrus.ParseLevel(logLevel)
@@ -48,6 +66,10 @@ func main() {
 
 			logrus.SetLevel(lvl)
 
+			if err = checkFormat(format); err != nil {
+				return c, err
+			}
+
 			return c, nil
 		},
 	}
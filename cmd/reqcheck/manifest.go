@@ -0,0 +1,309 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+)
+
+// manifestMinimumField is the field of a dependency requiring a minimum
+// version.
+const manifestMinimumField = "version>="
+
+type (
+	// projectManifest is the dependencies of a project consuming ports which
+	// require a particular version along with where the ports are found.
+	projectManifest struct {
+		Pins      map[string]manifestPin
+		PortPaths []string
+	}
+
+	// manifestPin is a version of a port required by a project through an
+	// override or a minimum version.
+	manifestPin struct {
+		Field       string
+		Version     string
+		PortVersion int
+	}
+
+	// manifestFile is the vcpkg.json of a project. The builtin-baseline is
+	// not read as only the versions required explicitly are checked.
+	manifestFile struct {
+		Dependencies  []manifestDependency     `json:"dependencies"`
+		Overrides     []map[string]interface{} `json:"overrides"`
+		Configuration *vcpkgConfiguration      `json:"vcpkg-configuration"`
+	}
+
+	// manifestDependency is a dependency of a project which is either the name
+	// of the port or an object.
+	manifestDependency struct {
+		Name           string `json:"name"`
+		MinimumVersion string `json:"version>="`
+	}
+
+	// vcpkgConfiguration is the vcpkg-configuration.json of a project.
+	vcpkgConfiguration struct {
+		DefaultRegistry *vcpkgRegistry  `json:"default-registry"`
+		Registries      []vcpkgRegistry `json:"registries"`
+		OverlayPorts    []string        `json:"overlay-ports"`
+	}
+
+	// vcpkgRegistry is a source of ports for a project.
+	vcpkgRegistry struct {
+		Kind       string   `json:"kind"`
+		Repository string   `json:"repository"`
+		Path       string   `json:"path"`
+		Baseline   string   `json:"baseline"`
+		Packages   []string `json:"packages"`
+	}
+)
+
+func vcpkgManifestCmd(settings *vcpkgSettings) *cli.Command {
+	return &cli.Command{
+		Name:      "manifest",
+		Usage:     "check the versions required by the manifest of a project",
+		ArgsUsage: "<project-path>",
+		Action: func(c context.Context, cmd *cli.Command) error {
+			if cmd.NArg() > 1 {
+				return fmt.Errorf("command takes one optional argument <project-path>: %w", ErrCli)
+			}
+			if err := checkGraphFormat(settings.Graph); err != nil {
+				return err
+			}
+
+			v, err := openVcpkgRepository(cmd.Args().Get(0), settings.Overlays)
+			if err != nil {
+				return err
+			}
+
+			manifest, err := loadProjectManifest(v.Path)
+			if err != nil {
+				return err
+			}
+			v.Manifest = &manifest
+
+			names := make([]string, 0, len(manifest.Pins))
+			for name := range manifest.Pins {
+				names = append(names, name)
+			}
+			if len(names) == 0 {
+				return fmt.Errorf("no dependencies have an override or minimum version: %w", ErrCli)
+			}
+			sort.Strings(names)
+
+			r, err := v.checkLibraries(c, cmd, *settings, names)
+			if err != nil {
+				return err
+			}
+
+			return writeReport(c, cmd, *settings, v.Config, r)
+		},
+	}
+}
+
+func (d *manifestDependency) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &d.Name); err == nil {
+		return nil
+	}
+
+	type dependency manifestDependency
+
+	return json.Unmarshal(b, (*dependency)(d))
+}
+
+// loadProjectManifest reads the manifest of a project along with its
+// configuration. The configuration is read from vcpkg-configuration.json
+// when present, otherwise from the manifest.
+func loadProjectManifest(projectPath string) (projectManifest, error) {
+	b, err := os.ReadFile(filepath.Join(projectPath, "vcpkg.json"))
+	if err != nil {
+		return projectManifest{}, fmt.Errorf("could not read project manifest: %w", err)
+	}
+
+	var file manifestFile
+	if err = json.Unmarshal(b, &file); err != nil {
+		return projectManifest{}, fmt.Errorf("could not parse project manifest: %w", err)
+	}
+
+	configuration := file.Configuration
+	b, err = os.ReadFile(filepath.Join(projectPath, "vcpkg-configuration.json"))
+	if err == nil {
+		configuration = &vcpkgConfiguration{}
+		if err = json.Unmarshal(b, configuration); err != nil {
+			return projectManifest{}, fmt.Errorf("could not parse vcpkg configuration: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return projectManifest{}, fmt.Errorf("could not read vcpkg configuration: %w", err)
+	}
+	if configuration == nil {
+		configuration = &vcpkgConfiguration{}
+	}
+
+	manifest := projectManifest{Pins: make(map[string]manifestPin)}
+
+	// Ports are found within the overlays then any registries on disk
+	resolve := func(path string) string {
+		if filepath.IsAbs(path) {
+			return path
+		}
+
+		return filepath.Join(projectPath, path)
+	}
+
+	for _, overlay := range configuration.OverlayPorts {
+		manifest.PortPaths = append(manifest.PortPaths, resolve(overlay))
+	}
+	for _, registry := range configuration.registries() {
+		if registry.Kind == "filesystem" && registry.Path != "" {
+			manifest.PortPaths = append(manifest.PortPaths, filepath.Join(resolve(registry.Path), "ports"))
+		}
+	}
+
+	for _, dependency := range file.Dependencies {
+		if dependency.MinimumVersion == "" {
+			continue
+		}
+
+		version, portVersion, err := splitPortVersion(dependency.MinimumVersion)
+		if err != nil {
+			return projectManifest{}, fmt.Errorf("invalid minimum version of %s: %w", dependency.Name, err)
+		}
+
+		manifest.Pins[dependency.Name] = manifestPin{
+			Field:       manifestMinimumField,
+			Version:     version,
+			PortVersion: portVersion,
+		}
+	}
+
+	// An override takes precedence over a minimum version
+	for _, override := range file.Overrides {
+		name, _ := override["name"].(string)
+		if name == "" {
+			return projectManifest{}, fmt.Errorf("override without a name: %w", ErrCli)
+		}
+
+		pin := manifestPin{}
+		for _, field := range vcpkgVersionFields {
+			if version, ok := override[field.Name].(string); ok {
+				pin.Field, pin.Version = field.Name, version
+				break
+			}
+		}
+		if pin.Field == "" {
+			return projectManifest{}, fmt.Errorf("override of %s has no version: %w", name, ErrCli)
+		}
+
+		if portVersion, ok := override["port-version"].(float64); ok {
+			pin.PortVersion = int(portVersion)
+		}
+
+		manifest.Pins[name] = pin
+	}
+
+	for name, pin := range manifest.Pins {
+		logrus.WithFields(logrus.Fields{
+			"library":  name,
+			"field":    pin.Field,
+			"version":  pin.Version,
+			"registry": configuration.registryName(name),
+		}).Debug("found pinned version")
+	}
+
+	return manifest, nil
+}
+
+// registries lists the registries in order of precedence.
+func (c vcpkgConfiguration) registries() []vcpkgRegistry {
+	registries := slices.Clone(c.Registries)
+	if c.DefaultRegistry != nil {
+		registries = append(registries, *c.DefaultRegistry)
+	}
+
+	return registries
+}
+
+// registryName describes the registry providing the port. Ports not listed
+// by a registry come from the default registry.
+func (c vcpkgConfiguration) registryName(port string) string {
+	registry := c.DefaultRegistry
+	for i := range c.Registries {
+		if slices.Contains(c.Registries[i].Packages, port) {
+			registry = &c.Registries[i]
+			break
+		}
+	}
+
+	if registry == nil {
+		return "builtin"
+	}
+
+	name := registry.Repository
+	if registry.Kind == "filesystem" {
+		name = registry.Path
+	}
+	if registry.Baseline != "" {
+		name += "@" + registry.Baseline
+	}
+
+	return name
+}
+
+// pinnedVersion is the version of a port required by the project. The scheme
+// is determined by the field of an override, or the port itself for a
+// minimum version, unless the library specifies one.
+func (v vcpkgRepository) pinnedVersion(name, schemeName string) (vcpkgVersion, error) {
+	pin, ok := v.Manifest.Pins[name]
+	if !ok {
+		return vcpkgVersion{}, fmt.Errorf("no version of %s is required by the project: %w", name, ErrCli)
+	}
+
+	// The port is not required when the library is configured
+	port, err := v.readVcpkgVersion(name, schemeName)
+	if err == nil && schemeName == "" && pin.Field == manifestMinimumField {
+		schemeName = port.Scheme.Name()
+	}
+
+	version, scheme, err := parseVersionField(name, pin.Field, pin.Version, schemeName)
+	if err != nil {
+		return vcpkgVersion{}, err
+	}
+
+	return vcpkgVersion{
+		Version:     version,
+		Scheme:      scheme,
+		Field:       pin.Field,
+		PortVersion: pin.PortVersion,
+		Path:        port.Path,
+	}, nil
+}
+
+// splitPortVersion separates a version such as 1.2.3#1 into the version and
+// port version.
+func splitPortVersion(s string) (string, int, error) {
+	version, portVersion, ok := strings.Cut(s, "#")
+	if !ok {
+		return s, 0, nil
+	}
+
+	n, err := strconv.Atoi(portVersion)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port version %s: %w", portVersion, ErrCli)
+	}
+
+	return version, n, nil
+}
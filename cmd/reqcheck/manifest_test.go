@@ -0,0 +1,154 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/WebKitForWindows/reqcheck"
+)
+
+// testProjectFiles is a project consuming ports from an overlay and a
+// registry on disk.
+var testProjectFiles = map[string]string{
+	"vcpkg.json": `{
+  "name": "project",
+  "builtin-baseline": "0123456789abcdef0123456789abcdef01234567",
+  "dependencies": [
+    "fmt",
+    {"name": "zlib", "version>=": "1.2.13#1"},
+    {"name": "curl", "version>=": "8.5.0"},
+    {"name": "sqlite3", "version>=": "3.45.1", "features": ["json1"]},
+    {"name": "icu", "version>=": "74.2"}
+  ],
+  "overrides": [
+    {"name": "curl", "version": "8.4.0", "port-version": 2},
+    {"name": "tzdata", "version-date": "2024-01-15"}
+  ]
+}`,
+	"vcpkg-configuration.json": `{
+  "default-registry": {"kind": "git", "repository": "https://github.com/microsoft/vcpkg"},
+  "registries": [
+    {"kind": "filesystem", "path": "registry", "packages": ["sqlite3"]}
+  ],
+  "overlay-ports": ["overlays"]
+}`,
+	"overlays/zlib/vcpkg.json":          `{"name": "zlib", "version": "1.3.1"}`,
+	"registry/ports/sqlite3/vcpkg.json": `{"name": "sqlite3", "version-date": "2024-01-30"}`,
+}
+
+func TestLoadProjectManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, testProjectFiles)
+
+	manifest, err := loadProjectManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An override takes precedence over a minimum version
+	expected := "map[curl:{version 8.4.0 2} icu:{version>= 74.2 0} sqlite3:{version>= 3.45.1 0} tzdata:{version-date 2024-01-15 0} zlib:{version>= 1.2.13 1}]"
+	if fmt.Sprint(manifest.Pins) != expected {
+		t.Errorf("unexpected pins %v", manifest.Pins)
+	}
+
+	if fmt.Sprint(manifest.PortPaths) != fmt.Sprint([]string{filepath.Join(dir, "overlays"), filepath.Join(dir, "registry", "ports")}) {
+		t.Errorf("unexpected port paths %v", manifest.PortPaths)
+	}
+}
+
+func TestLoadProjectManifestConfiguration(t *testing.T) {
+	dir := t.TempDir()
+
+	// Without a baseline or a configuration file the manifest is still read
+	writeFiles(t, dir, map[string]string{
+		"vcpkg.json": `{
+  "dependencies": [{"name": "zlib", "version>=": "1.3"}],
+  "vcpkg-configuration": {"overlay-ports": ["/ports"]}
+}`,
+	})
+
+	manifest, err := loadProjectManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(manifest.Pins) != "map[zlib:{version>= 1.3 0}]" || fmt.Sprint(manifest.PortPaths) != "[/ports]" {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+}
+
+func TestLoadProjectManifestErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing name":         `{"overrides": [{"version": "1.0"}]}`,
+		"missing version":      `{"overrides": [{"name": "zlib", "port-version": 1}]}`,
+		"invalid port version": `{"dependencies": [{"name": "zlib", "version>=": "1.3#one"}]}`,
+		"invalid json":         `{"dependencies": 1}`,
+	}
+
+	for name, file := range tests {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"vcpkg.json": file})
+
+		if _, err := loadProjectManifest(dir); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := loadProjectManifest(t.TempDir()); err == nil {
+		t.Error("expected an error without a manifest")
+	}
+}
+
+func TestPinnedVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, testProjectFiles)
+
+	manifest, err := loadProjectManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := vcpkgRepository{Path: dir, Manifest: &manifest}
+
+	tests := []struct {
+		name     string
+		scheme   string
+		expected string
+	}{
+		// A minimum version is read with the scheme of the port
+		{"zlib", "", "semver 1.2.13#1 " + filepath.Join(dir, "overlays", "zlib")},
+		{"sqlite3", "", ""},
+		// Unless the library specifies one
+		{"sqlite3", reqcheck.SchemeDotted, "dotted 3.45.1#0 " + filepath.Join(dir, "registry", "ports", "sqlite3")},
+		// A port that cannot be found is not needed
+		{"icu", "", "semver 74.2.0#0 "},
+		{"curl", "", "semver 8.4.0#2 "},
+		{"tzdata", "", "date 2024-01-15#0 "},
+		{"fmt", "", ""},
+	}
+
+	for _, test := range tests {
+		port, err := v.pinnedVersion(test.name, test.scheme)
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %v", test.name, port.Version)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+
+		actual := fmt.Sprintf("%s %s#%d %s", port.Scheme.Name(), port.Version, port.PortVersion, port.Path)
+		if actual != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/WebKitForWindows/reqcheck"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	notifierSlack   = "slack"
+	notifierTeams   = "teams"
+	notifierDiscord = "discord"
+	notifierWebhook = "webhook"
+)
+
+type (
+	// notifier delivers the results of the vcpkg command.
+	notifier interface {
+		// messages creates the bodies of the requests delivering the report.
+		messages(r report) ([][]byte, error)
+		// send delivers a single message.
+		send(ctx context.Context, cl *http.Client, body []byte) error
+	}
+
+	// notifierConfig is an entry within the notify section of the config.
+	notifierConfig struct {
+		Type     string
+		notifier notifier
+	}
+)
+
+func (n *notifierConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	un := make(map[string]interface{})
+
+	err := unmarshal(&un)
+	if err != nil {
+		return err
+	}
+
+	n.Type, _ = un["type"].(string)
+
+	switch n.Type {
+	case notifierSlack:
+		var s slackConfig
+		if err = unmarshal(&s); err != nil {
+			return err
+		}
+		n.notifier = s
+	case notifierTeams:
+		n.notifier, err = newTeamsNotifier(un)
+	case notifierDiscord:
+		n.notifier, err = newDiscordNotifier(un)
+	case notifierWebhook:
+		n.notifier, err = newWebhookNotifier(un)
+	default:
+		return fmt.Errorf("unknown notifier %s: %w", n.Type, ErrCli)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s notifier: %w", n.Type, err)
+	}
+
+	return nil
+}
+
+// notifiers returns everything configured to receive the results.
+func (c config) notifiers() []notifier {
+	notifiers := make([]notifier, 0, len(c.Notify)+1)
+
+	if c.Slack.configured() {
+		notifiers = append(notifiers, c.Slack)
+	}
+	for _, n := range c.Notify {
+		notifiers = append(notifiers, n.notifier)
+	}
+
+	return notifiers
+}
+
+// writeNotifications outputs the messages of every notifier rather than
+// delivering them.
+func writeNotifications(w io.Writer, notifiers []notifier, r report) error {
+	for _, n := range notifiers {
+		messages, err := n.messages(r)
+		if err != nil {
+			return fmt.Errorf("could not create notification: %w", err)
+		}
+
+		for _, message := range messages {
+			if _, err = fmt.Fprintf(w, "%s\n", bytes.TrimSpace(message)); err != nil {
+				return fmt.Errorf("could not write notification: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sendNotifications delivers the report to every notifier. A failure to
+// deliver to one does not stop delivery to the others.
+func sendNotifications(ctx context.Context, notifiers []notifier, r report) error {
+	cl := &http.Client{Transport: reqcheck.NewRetryTransport(http.DefaultTransport, reqcheck.DefaultRetryOptions())}
+
+	var errs []error
+
+	for _, n := range notifiers {
+		messages, err := n.messages(r)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not create notification: %w", err))
+			continue
+		}
+
+		for i, message := range messages {
+			logrus.WithFields(logrus.Fields{
+				"notifier": fmt.Sprintf("%T", n),
+				"message":  i + 1,
+			}).Debug("sending notification")
+
+			if err = n.send(ctx, cl, message); err != nil {
+				errs = append(errs, fmt.Errorf("could not send notification %d of %d: %w", i+1, len(messages), err))
+				break
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// postBody sends the body to the url and returns the response of a
+// successful request.
+func postBody(ctx context.Context, cl *http.Client, method, u string, header http.Header, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+
+	resp, err := cl.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %s: %s: %w", resp.Status, strings.TrimSpace(string(b)), ErrCli)
+	}
+
+	return b, nil
+}
+
+// marshalMessage encodes the message without escaping the characters used
+// for links.
+func marshalMessage(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+// requireString reads a required setting of a notifier.
+func requireString(un map[string]interface{}, key string) (string, error) {
+	s, err := parseSecret(un[key])
+	if err != nil {
+		return "", err
+	}
+	if s == "" {
+		return "", fmt.Errorf("no %s provided: %w", key, ErrCli)
+	}
+
+	return s, nil
+}
+
+// splitLines joins the lines into pieces no longer than limit.
+func splitLines(lines []string, limit int) []string {
+	var pieces []string
+	var sb strings.Builder
+
+	for _, line := range lines {
+		if sb.Len() > 0 && sb.Len()+1+len(line) > limit {
+			pieces = append(pieces, sb.String())
+			sb.Reset()
+		}
+
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(line)
+	}
+
+	if sb.Len() > 0 {
+		pieces = append(pieces, sb.String())
+	}
+
+	return pieces
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseNotifiers reads the notify section of a config.
+func parseNotifiers(t *testing.T, text string) []notifier {
+	t.Helper()
+
+	var c config
+	if err := yaml.Unmarshal([]byte(text), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	return c.notifiers()
+}
+
+func TestNotifierConfig(t *testing.T) {
+	t.Setenv("REQCHECK_TEST_TOKEN", "secret")
+
+	notifiers := parseNotifiers(t, `
+slack:
+  token: xoxb
+  channel: "#builds"
+notify:
+  - type: teams
+    webhook: https://example.com/teams
+  - type: discord
+    webhook: https://example.com/discord
+    username: reqcheck
+  - type: webhook
+    url: https://example.com/hook
+    method: put
+    headers:
+      Authorization:
+        from_environment: REQCHECK_TEST_TOKEN
+`)
+
+	if len(notifiers) != 4 {
+		t.Fatalf("expected 4 notifiers, got %d", len(notifiers))
+	}
+	if s, ok := notifiers[0].(slackConfig); !ok || s.Channel != "#builds" {
+		t.Errorf("unexpected slack notifier %#v", notifiers[0])
+	}
+	if d, ok := notifiers[2].(discordNotifier); !ok || d.Username != "reqcheck" {
+		t.Errorf("unexpected discord notifier %#v", notifiers[2])
+	}
+
+	w, ok := notifiers[3].(webhookNotifier)
+	if !ok || w.Method != http.MethodPut || w.Header.Get("Authorization") != "secret" {
+		t.Errorf("unexpected webhook notifier %#v", notifiers[3])
+	}
+}
+
+func TestNotifierConfigErrors(t *testing.T) {
+	tests := []string{
+		"notify:\n  - type: pager\n",
+		"notify:\n  - type: teams\n",
+		"notify:\n  - type: webhook\n    url: https://example.com\n    template: '{{ .Missing'\n",
+	}
+
+	for _, text := range tests {
+		var c config
+		if err := yaml.Unmarshal([]byte(text), &c); err == nil {
+			t.Errorf("expected an error for %q", text)
+		}
+	}
+}
+
+func TestTeamsMessages(t *testing.T) {
+	r := testReport(3)
+	for i := 0; i < 60; i++ {
+		r.Upgrade = append(r.Upgrade, releaseUpdate{Name: fmt.Sprintf("up%03d", i), Current: "1.0.0", Upgrade: "2.0.0"})
+	}
+
+	bodies, err := teamsNotifier{Webhook: "https://example.com"}.messages(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The 61 upgrades fill the first card and continue on another
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 cards, got %d", len(bodies))
+	}
+
+	var message teamsMessage
+	if err = json.Unmarshal(bodies[0], &message); err != nil {
+		t.Fatal(err)
+	}
+
+	card := message.Attachments[0].Content
+	if message.Type != "message" || card.Type != "AdaptiveCard" || card.Body[1]["text"] != r.summary() {
+		t.Errorf("unexpected card %+v", message)
+	}
+	if card.Body[3]["text"] != "lib000 1.0.0, lib001 1.0.0, lib002 1.0.0" {
+		t.Errorf("unexpected current libraries %v", card.Body[3]["text"])
+	}
+	if facts := card.Body[5]["facts"].([]interface{}); len(facts) != teamsMaxFacts {
+		t.Errorf("expected %d facts, got %d", teamsMaxFacts, len(facts))
+	}
+	if !strings.Contains(string(bodies[0]), "8.9.0#1 → [8.10.0](https://github.com/curl/curl/releases/tag/curl-8_10_0) (published 2026-09-01)") {
+		t.Errorf("unexpected upgrade in %s", bodies[0])
+	}
+	if !strings.Contains(string(bodies[1]), "rate limited <retry later>") {
+		t.Errorf("expected the failure on the second card, got %s", bodies[1])
+	}
+}
+
+func TestDiscordMessages(t *testing.T) {
+	r := testReport(0)
+	for i := 0; i < 1000; i++ {
+		r.Current = append(r.Current, releaseUpdate{Name: fmt.Sprintf("library-with-a-long-name-%04d", i), Current: "1.0.0"})
+	}
+
+	bodies, err := discordNotifier{Webhook: "https://example.com", Username: "reqcheck"}.messages(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var embeds int
+	for i, body := range bodies {
+		var message discordMessage
+		if err = json.Unmarshal(body, &message); err != nil {
+			t.Fatal(err)
+		}
+
+		size := 0
+		for _, embed := range message.Embeds {
+			if len(embed.Description) > discordMaxDescription {
+				t.Errorf("embed of %d characters", len(embed.Description))
+			}
+			size += len(embed.Title) + len(embed.Description)
+		}
+
+		if len(message.Embeds) > discordMaxEmbeds || size > discordMaxMessage {
+			t.Errorf("message %d has %d embeds of %d characters", i, len(message.Embeds), size)
+		}
+		if message.Username != "reqcheck" || (i == 0) != (message.Content != "") {
+			t.Errorf("unexpected message %d %q %q", i, message.Username, message.Content)
+		}
+
+		embeds += len(message.Embeds)
+	}
+
+	if len(bodies) < 2 || embeds < 3 {
+		t.Errorf("expected the libraries to be split, got %d embeds in %d messages", embeds, len(bodies))
+	}
+}
+
+func TestWebhookMessages(t *testing.T) {
+	notifiers := parseNotifiers(t, `
+notify:
+  - type: webhook
+    url: https://example.com/hook
+  - type: webhook
+    url: https://example.com/hook
+    template: |
+      {"text": {{ json .Summary }}, "upgrades": [{{ range $i, $u := .Upgrade }}{{ if $i }},{{ end }}{{ json $u.Name }}{{ end }}]}
+`)
+
+	bodies, err := notifiers[0].messages(testReport(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var results []libraryResult
+	if err = json.Unmarshal(bodies[0], &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[0].Status != statusCurrent || results[1].Status != statusUpgrade || results[2].Error == "" {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	bodies, err = notifiers[1].messages(testReport(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bodies[0]) != `{"text": "1 up to date, 1 with updates, 1 could not be checked", "upgrades": ["curl"]}` {
+		t.Errorf("unexpected body %s", bodies[0])
+	}
+}
+
+func TestSendNotifications(t *testing.T) {
+	server, requests, bodies := newNotifyServer(t, "")
+
+	notifiers := parseNotifiers(t, fmt.Sprintf(`
+notify:
+  - type: teams
+    webhook: %[1]s/teams
+  - type: discord
+    webhook: %[1]s/discord
+  - type: webhook
+    url: %[1]s/hook
+    method: put
+    headers:
+      X-Token: secret
+`, server.URL))
+
+	if err := sendNotifications(context.Background(), notifiers, testReport(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	var sent []string
+	for _, req := range *requests {
+		sent = append(sent, req.Method+" "+req.URL.Path)
+	}
+	if fmt.Sprint(sent) != "[POST /teams POST /discord PUT /hook]" {
+		t.Errorf("unexpected requests %v", sent)
+	}
+	if (*requests)[2].Header.Get("X-Token") != "secret" || (*requests)[2].Header.Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("unexpected headers %v", (*requests)[2].Header)
+	}
+	for i, body := range *bodies {
+		if !json.Valid(body) {
+			t.Errorf("request %d is not json: %s", i, body)
+		}
+	}
+}
+
+func TestSendNotificationsNotRetried(t *testing.T) {
+	var count atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	notifiers := []notifier{
+		teamsNotifier{Webhook: server.URL},
+		discordNotifier{Webhook: server.URL},
+	}
+
+	// A failed post may have been delivered so it is never sent again, and a
+	// failure does not stop the other notifiers
+	err := sendNotifications(context.Background(), notifiers, testReport(1))
+	if err == nil || !strings.Contains(err.Error(), "unavailable") {
+		t.Errorf("expected the failures to be reported, got %v", err)
+	}
+	if count.Load() != 2 {
+		t.Errorf("expected a single attempt for each notifier, got %d", count.Load())
+	}
+}
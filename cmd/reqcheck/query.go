@@ -7,8 +7,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
-	"github.com/Masterminds/semver"
 	"github.com/WebKitForWindows/reqcheck"
 	"github.com/urfave/cli/v3"
 )
@@ -18,8 +20,15 @@ type querySettings struct {
 	Token      string
 	Tags       bool
 	Prerelease bool
+	Draft      bool
+	Pattern    string
+	Prefix     string
+	Suffix     string
+	Exclude    []string
+	Scheme     string
 	Constraint string
 	LimitTo    int
+	GraphQL    bool
 }
 
 func queryAction(driver string, settings *querySettings) func(c context.Context, cmd *cli.Command) error {
@@ -35,44 +44,101 @@ func queryAction(driver string, settings *querySettings) func(c context.Context,
 		owner := cmd.Args().Get(0)
 		repo := cmd.Args().Get(1)
 
-		client, err := reqcheck.NewClientFromDriver(driver, settings.URI, settings.Token)
+		client, err := reqcheck.NewClientFromDriverWithOptions(driver, settings.URI, settings.Token, reqcheck.ClientOptions{
+			Cache:   cacheFromFlags(cmd),
+			GraphQL: settings.GraphQL,
+		})
 		if err != nil {
 			return fmt.Errorf("could not connect to %s server at %s: %w", driver, settings.URI, err)
 		}
 
+		scheme, err := reqcheck.SchemeFromName(settings.Scheme)
+		if err != nil {
+			return fmt.Errorf("could not determine version scheme: %w", err)
+		}
+
 		releaseOpts := reqcheck.ListReleaseOptions{
-			Owner:   owner,
-			Repo:    repo,
-			Tags:    settings.Tags,
-			LimitTo: settings.LimitTo,
+			Scheme:             scheme,
+			Owner:              owner,
+			Repo:               repo,
+			Tags:               settings.Tags,
+			LimitTo:            settings.LimitTo,
+			ExcludeDrafts:      !settings.Draft,
+			ExcludePrereleases: !settings.Prerelease,
+			Context:            c,
+		}
+
+		if settings.Pattern != "" || settings.Prefix != "" || settings.Suffix != "" || len(settings.Exclude) > 0 {
+			releaseOpts.Version, err = reqcheck.NewVersionPattern(settings.Pattern, settings.Prefix, settings.Suffix, settings.Exclude)
+			if err != nil {
+				return fmt.Errorf("could not create version pattern: %w", err)
+			}
 		}
 
-		observer := reqcheck.ListReleases(client, releaseOpts)
+		releases := reqcheck.Releases(client, releaseOpts)
 
 		if settings.Constraint != "" {
-			constraint, err := semver.NewConstraint(settings.Constraint)
+			constraint, err := reqcheck.NewConstraint(scheme, settings.Constraint)
 			if err != nil {
 				return fmt.Errorf("could not parse constraint %s: %w", settings.Constraint, err)
 			}
 
-			observer = observer.Filter(reqcheck.FilterSemanticConstraint(constraint))
+			releases = reqcheck.Filter(releases, reqcheck.SatisfiesConstraint(constraint))
 		} else if !settings.Prerelease {
-			observer = observer.Filter(reqcheck.FilterStableRelease)
+			releases = reqcheck.Filter(releases, reqcheck.IsStableRelease)
 		}
 
-		for item := range observer.Observe() {
-			if item.Error() {
+		format := cmd.String("format")
+		results := make([]releaseResult, 0)
+
+		for release, err := range releases {
+			if err != nil {
 				return fmt.Errorf("error when getting releases from %s/%s/%s: %w", settings.URI, owner, repo, err)
 			}
 
-			release := item.V.(reqcheck.Release)
-			if release.SemVer != nil {
-				fmt.Printf("tag %s -> semver %s\n", release.Tag, release.SemVer.String())
+			if format != formatText {
+				results = append(results, newReleaseResult(release, scheme))
+			} else if release.Version != nil {
+				fmt.Printf("tag %s -> %s %s%s\n", release.Tag, scheme.Name(), release.Version.String(), releaseDetails(release))
 			} else {
-				fmt.Printf("tag %s -> semver ???\n", release.Tag)
+				fmt.Printf("tag %s -> %s ???%s\n", release.Tag, scheme.Name(), releaseDetails(release))
+			}
+		}
+
+		if format != formatText {
+			if err = writeResults(os.Stdout, format, results); err != nil {
+				return fmt.Errorf("could not write results: %w", err)
 			}
 		}
 
 		return nil
 	}
 }
+
+// releaseDetails formats the metadata known about the release.
+func releaseDetails(release reqcheck.Release) string {
+	details := make([]string, 0, 4)
+
+	if !release.PublishedAt.IsZero() {
+		details = append(details, "published "+release.PublishedAt.Format(time.DateOnly))
+	}
+	if release.Commit != "" {
+		details = append(details, "commit "+release.Commit)
+	}
+	if release.Draft {
+		details = append(details, "draft")
+	}
+	if release.Prerelease {
+		details = append(details, "pre-release")
+	}
+
+	var s string
+	if len(details) > 0 {
+		s = " (" + strings.Join(details, ", ") + ")"
+	}
+	if release.URL != "" {
+		s += " " + release.URL
+	}
+
+	return s
+}
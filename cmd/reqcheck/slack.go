@@ -0,0 +1,227 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	slackAPIURL = "https://slack.com/api/"
+	// slackMaxBlocks is the most blocks Slack accepts in a message.
+	slackMaxBlocks = 50
+	// slackMaxText is the most characters Slack accepts in a section.
+	slackMaxText = 3000
+)
+
+type (
+	// slackConfig is how messages are delivered to Slack. Either an incoming
+	// webhook is used or a bot token posting to a channel.
+	slackConfig struct {
+		Webhook string
+		Token   string
+		Channel string
+		APIURL  string
+	}
+
+	slackText struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+
+	slackBlock struct {
+		Type     string      `json:"type"`
+		Text     *slackText  `json:"text,omitempty"`
+		Elements []slackText `json:"elements,omitempty"`
+	}
+
+	slackMessage struct {
+		Channel string       `json:"channel,omitempty"`
+		Text    string       `json:"text"`
+		Blocks  []slackBlock `json:"blocks"`
+	}
+)
+
+func (s *slackConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	un := make(map[string]interface{})
+
+	err := unmarshal(&un)
+	if err != nil {
+		return err
+	}
+
+	if s.Webhook, err = parseSecret(un["webhook"]); err != nil {
+		return fmt.Errorf("invalid slack webhook: %w", err)
+	}
+
+	if s.Token, err = parseSecret(un["token"]); err != nil {
+		return fmt.Errorf("invalid slack token: %w", err)
+	}
+
+	if channel, ok := un["channel"].(string); ok {
+		s.Channel = channel
+	}
+
+	if apiURL, ok := un["api_url"].(string); ok {
+		s.APIURL = apiURL
+	}
+
+	return nil
+}
+
+// configured determines if messages can be delivered.
+func (s slackConfig) configured() bool {
+	return s.Webhook != "" || s.Token != ""
+}
+
+func (s slackConfig) messages(r report) ([][]byte, error) {
+	// Webhooks always post to their own channel
+	channel := s.Channel
+	if s.Webhook != "" {
+		channel = ""
+	}
+
+	var bodies [][]byte
+	for _, message := range slackMessages(channel, r) {
+		body, err := marshalMessage(message)
+		if err != nil {
+			return nil, err
+		}
+
+		bodies = append(bodies, body)
+	}
+
+	return bodies, nil
+}
+
+func (s slackConfig) send(ctx context.Context, cl *http.Client, body []byte) error {
+	if s.Webhook != "" {
+		_, err := postBody(ctx, cl, http.MethodPost, s.Webhook, nil, body)
+
+		return err
+	}
+
+	apiURL := s.APIURL
+	if apiURL == "" {
+		apiURL = slackAPIURL
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+s.Token)
+
+	b, err := postBody(ctx, cl, http.MethodPost, strings.TrimSuffix(apiURL, "/")+"/chat.postMessage", header, body)
+	if err != nil {
+		return err
+	}
+
+	// The web API reports errors in the body
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err = json.Unmarshal(b, &result); err != nil {
+		return fmt.Errorf("could not decode slack response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack returned %s: %w", result.Error, ErrCli)
+	}
+
+	return nil
+}
+
+// slackMessages creates the messages reporting the results. The results are
+// split across messages when there are too many blocks for one.
+func slackMessages(channel string, r report) []slackMessage {
+	summary := "Requirements check: " + r.summary()
+
+	blocks := []slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: "Requirements check"}},
+		{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: summary}}},
+	}
+
+	if len(r.Upgrade) > 0 {
+		blocks = append(blocks, slackSection("*The following libraries have updates*"))
+
+		for _, release := range r.Upgrade {
+			latest := release.Upgrade
+			if release.URL != "" {
+				latest = fmt.Sprintf("<%s|%s>", release.URL, slackEscape(release.Upgrade))
+			}
+
+			text := fmt.Sprintf("*%s*: %s → %s", slackEscape(release.Name), slackEscape(release.currentString()), latest)
+			if !release.PublishedAt.IsZero() {
+				text += fmt.Sprintf(" (published %s)", release.PublishedAt.Format(time.DateOnly))
+			}
+
+			blocks = append(blocks, slackSection(text))
+		}
+	}
+
+	if len(r.Errors) > 0 {
+		blocks = append(blocks, slackSection("*The following libraries could not be checked*"))
+
+		for _, failure := range r.Errors {
+			blocks = append(blocks, slackSection(fmt.Sprintf("*%s*: %s", slackEscape(failure.Name), slackEscape(failure.Reason))))
+		}
+	}
+
+	if len(r.Current) > 0 {
+		names := make([]string, len(r.Current))
+		for i, release := range r.Current {
+			names[i] = fmt.Sprintf("%s %s", slackEscape(release.Name), slackEscape(release.currentString()))
+		}
+
+		blocks = append(blocks, slackSection("*The following libraries are up to date*"))
+		for _, text := range splitText(strings.Join(names, ", "), slackMaxText) {
+			blocks = append(blocks, slackSection(text))
+		}
+	}
+
+	var messages []slackMessage
+	for start := 0; start < len(blocks); start += slackMaxBlocks {
+		messages = append(messages, slackMessage{
+			Channel: channel,
+			Text:    summary,
+			Blocks:  blocks[start:min(start+slackMaxBlocks, len(blocks))],
+		})
+	}
+
+	return messages
+}
+
+func slackSection(text string) slackBlock {
+	if runes := []rune(text); len(runes) > slackMaxText {
+		text = string(runes[:slackMaxText-1]) + "…"
+	}
+
+	return slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}}
+}
+
+// slackEscape escapes the characters Slack treats as control characters.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// splitText breaks a comma separated list into pieces no longer than limit.
+func splitText(s string, limit int) []string {
+	var pieces []string
+
+	for len(s) > limit {
+		i := strings.LastIndex(s[:limit], ", ")
+		if i <= 0 {
+			i = limit
+		}
+
+		pieces = append(pieces, s[:i])
+		s = strings.TrimPrefix(s[i:], ", ")
+	}
+
+	return append(pieces, s)
+}
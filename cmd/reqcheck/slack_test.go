@@ -0,0 +1,195 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testReport has an upgrade, a failure and the number of current libraries
+// given.
+func testReport(current int) report {
+	r := report{
+		Upgrade: []releaseUpdate{{
+			Name:        "curl",
+			Current:     "8.9.0",
+			PortVersion: 1,
+			Upgrade:     "8.10.0",
+			URL:         "https://github.com/curl/curl/releases/tag/curl-8_10_0",
+			PublishedAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		Errors: []releaseError{{Name: "icu", Reason: "rate limited <retry later>"}},
+	}
+
+	for i := 0; i < current; i++ {
+		r.Current = append(r.Current, releaseUpdate{Name: fmt.Sprintf("lib%03d", i), Current: "1.0.0"})
+	}
+
+	return r
+}
+
+// newNotifyServer records the requests made to it and responds with the
+// body given.
+func newNotifyServer(t *testing.T, response string) (*httptest.Server, *[]*http.Request, *[][]byte) {
+	t.Helper()
+
+	var requests []*http.Request
+	var bodies [][]byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		requests = append(requests, r)
+		bodies = append(bodies, b)
+
+		_, _ = io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests, &bodies
+}
+
+func TestSlackMessages(t *testing.T) {
+	messages := slackMessages("#builds", testReport(2))
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+
+	message := messages[0]
+	if message.Channel != "#builds" || message.Text != "Requirements check: 2 up to date, 1 with updates, 1 could not be checked" {
+		t.Errorf("unexpected message %s %q", message.Channel, message.Text)
+	}
+
+	var texts []string
+	for _, block := range message.Blocks {
+		if block.Text != nil {
+			texts = append(texts, block.Text.Text)
+		}
+	}
+
+	expected := []string{
+		"Requirements check",
+		"*The following libraries have updates*",
+		"*curl*: 8.9.0#1 → <https://github.com/curl/curl/releases/tag/curl-8_10_0|8.10.0> (published 2026-09-01)",
+		"*The following libraries could not be checked*",
+		"*icu*: rate limited &lt;retry later&gt;",
+		"*The following libraries are up to date*",
+		"lib000 1.0.0, lib001 1.0.0",
+	}
+	if strings.Join(texts, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected blocks\n%s", strings.Join(texts, "\n"))
+	}
+}
+
+func TestSlackMessagesSplit(t *testing.T) {
+	r := testReport(0)
+	for i := 0; i < 60; i++ {
+		r.Upgrade = append(r.Upgrade, releaseUpdate{Name: fmt.Sprintf("lib%03d", i), Current: "1.0.0", Upgrade: "2.0.0"})
+	}
+
+	messages := slackMessages("", r)
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+
+	// The header, context, two headings, 61 upgrades and the failure
+	if len(messages[0].Blocks) != slackMaxBlocks || len(messages[1].Blocks) != 66-slackMaxBlocks {
+		t.Errorf("unexpected blocks %d and %d", len(messages[0].Blocks), len(messages[1].Blocks))
+	}
+	if messages[1].Text != messages[0].Text {
+		t.Errorf("expected the summary in every message, got %q", messages[1].Text)
+	}
+}
+
+func TestSlackSectionLimit(t *testing.T) {
+	block := slackSection(strings.Repeat("é", slackMaxText+10))
+	if runes := []rune(block.Text.Text); len(runes) != slackMaxText || runes[len(runes)-1] != '…' {
+		t.Errorf("expected the text to be truncated to %d characters, got %d", slackMaxText, len(runes))
+	}
+
+	pieces := splitText(strings.Repeat("lib 1.0.0, ", 500), slackMaxText)
+	for _, piece := range pieces {
+		if len(piece) > slackMaxText || strings.HasPrefix(piece, ", ") {
+			t.Errorf("unexpected piece of %d characters", len(piece))
+		}
+	}
+	if len(pieces) != 2 {
+		t.Errorf("expected 2 pieces, got %d", len(pieces))
+	}
+}
+
+func TestSlackWebhook(t *testing.T) {
+	server, requests, bodies := newNotifyServer(t, "ok")
+
+	err := sendNotifications(context.Background(), []notifier{slackConfig{Webhook: server.URL + "/hook", Channel: "#builds"}}, testReport(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*requests) != 1 || (*requests)[0].URL.Path != "/hook" {
+		t.Fatalf("expected a single request to the webhook, got %d", len(*requests))
+	}
+	if (*requests)[0].Header.Get("Authorization") != "" {
+		t.Error("expected no authorization for a webhook")
+	}
+
+	// Webhooks always post to their own channel
+	var message slackMessage
+	if err = json.Unmarshal((*bodies)[0], &message); err != nil {
+		t.Fatal(err)
+	}
+	if message.Channel != "" || len(message.Blocks) == 0 {
+		t.Errorf("unexpected message %+v", message)
+	}
+	if !strings.Contains(string((*bodies)[0]), "<https://github.com/curl/curl/releases/tag/curl-8_10_0|8.10.0>") {
+		t.Errorf("expected links to be unescaped, got %s", (*bodies)[0])
+	}
+}
+
+func TestSlackBot(t *testing.T) {
+	server, requests, bodies := newNotifyServer(t, `{"ok": true}`)
+
+	s := slackConfig{Token: "xoxb-secret", Channel: "#builds", APIURL: server.URL + "/api/"}
+	if err := sendNotifications(context.Background(), []notifier{s}, testReport(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	req := (*requests)[0]
+	if req.URL.Path != "/api/chat.postMessage" || req.Header.Get("Authorization") != "Bearer xoxb-secret" {
+		t.Errorf("unexpected request %s %s", req.URL.Path, req.Header.Get("Authorization"))
+	}
+	if req.Header.Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("unexpected content type %s", req.Header.Get("Content-Type"))
+	}
+
+	var message slackMessage
+	if err := json.Unmarshal((*bodies)[0], &message); err != nil {
+		t.Fatal(err)
+	}
+	if message.Channel != "#builds" {
+		t.Errorf("expected the channel, got %q", message.Channel)
+	}
+}
+
+func TestSlackBotError(t *testing.T) {
+	server, _, _ := newNotifyServer(t, `{"ok": false, "error": "channel_not_found"}`)
+
+	s := slackConfig{Token: "xoxb-secret", Channel: "#missing", APIURL: server.URL}
+	err := sendNotifications(context.Background(), []notifier{s}, testReport(1))
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("expected the slack error, got %v", err)
+	}
+}
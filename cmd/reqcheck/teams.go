@@ -0,0 +1,145 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// teamsMaxFacts is the number of libraries listed within a single card.
+const teamsMaxFacts = 50
+
+type (
+	// teamsNotifier posts an Adaptive Card to a Microsoft Teams webhook.
+	teamsNotifier struct {
+		Webhook string
+	}
+
+	teamsMessage struct {
+		Type        string            `json:"type"`
+		Attachments []teamsAttachment `json:"attachments"`
+	}
+
+	teamsAttachment struct {
+		ContentType string    `json:"contentType"`
+		Content     teamsCard `json:"content"`
+	}
+
+	teamsCard struct {
+		Schema  string                   `json:"$schema"`
+		Type    string                   `json:"type"`
+		Version string                   `json:"version"`
+		Body    []map[string]interface{} `json:"body"`
+	}
+
+	teamsFact struct {
+		Title string `json:"title"`
+		Value string `json:"value"`
+	}
+)
+
+func newTeamsNotifier(un map[string]interface{}) (notifier, error) {
+	webhook, err := requireString(un, "webhook")
+	if err != nil {
+		return nil, err
+	}
+
+	return teamsNotifier{Webhook: webhook}, nil
+}
+
+func (n teamsNotifier) messages(r report) ([][]byte, error) {
+	var upgrades, failures []teamsFact
+
+	for _, release := range r.Upgrade {
+		latest := release.Upgrade
+		if release.URL != "" {
+			latest = fmt.Sprintf("[%s](%s)", release.Upgrade, release.URL)
+		}
+
+		value := fmt.Sprintf("%s → %s", release.currentString(), latest)
+		if !release.PublishedAt.IsZero() {
+			value += fmt.Sprintf(" (published %s)", release.PublishedAt.Format(time.DateOnly))
+		}
+
+		upgrades = append(upgrades, teamsFact{Title: release.Name, Value: value})
+	}
+
+	for _, failure := range r.Errors {
+		failures = append(failures, teamsFact{Title: failure.Name, Value: failure.Reason})
+	}
+
+	names := make([]string, len(r.Current))
+	for i, release := range r.Current {
+		names[i] = fmt.Sprintf("%s %s", release.Name, release.currentString())
+	}
+
+	// The first card carries the summary and libraries that are up to date
+	body := []map[string]interface{}{
+		{"type": "TextBlock", "text": "Requirements check", "size": "Large", "weight": "Bolder"},
+		{"type": "TextBlock", "text": r.summary(), "wrap": true, "isSubtle": true},
+	}
+	if len(names) > 0 {
+		body = append(body,
+			map[string]interface{}{"type": "TextBlock", "text": "The following libraries are up to date", "weight": "Bolder"},
+			map[string]interface{}{"type": "TextBlock", "text": strings.Join(names, ", "), "wrap": true},
+		)
+	}
+
+	cards := [][]map[string]interface{}{body}
+	cards = appendTeamsFacts(cards, "The following libraries have updates", upgrades)
+	cards = appendTeamsFacts(cards, "The following libraries could not be checked", failures)
+
+	var bodies [][]byte
+	for _, card := range cards {
+		b, err := marshalMessage(teamsMessage{
+			Type: "message",
+			Attachments: []teamsAttachment{{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content: teamsCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body:    card,
+				},
+			}},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		bodies = append(bodies, b)
+	}
+
+	return bodies, nil
+}
+
+func (n teamsNotifier) send(ctx context.Context, cl *http.Client, body []byte) error {
+	_, err := postBody(ctx, cl, http.MethodPost, n.Webhook, nil, body)
+
+	return err
+}
+
+// appendTeamsFacts adds the facts under a heading, starting another card
+// whenever the current one is full.
+func appendTeamsFacts(cards [][]map[string]interface{}, heading string, facts []teamsFact) [][]map[string]interface{} {
+	for start := 0; start < len(facts); start += teamsMaxFacts {
+		last := len(cards) - 1
+		if start > 0 {
+			cards = append(cards, nil)
+			last++
+		}
+
+		cards[last] = append(cards[last],
+			map[string]interface{}{"type": "TextBlock", "text": heading, "weight": "Bolder", "separator": true},
+			map[string]interface{}{"type": "FactSet", "facts": facts[start:min(start+teamsMaxFacts, len(facts))]},
+		)
+	}
+
+	return cards
+}
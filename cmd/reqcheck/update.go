@@ -0,0 +1,705 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/WebKitForWindows/reqcheck"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+)
+
+// commitRegex matches a REF which is a commit rather than a tag.
+var commitRegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// vcpkgFieldMatchers match the versions accepted by each version field of a
+// manifest.
+var vcpkgFieldMatchers = map[string]*regexp.Regexp{
+	"version-semver": regexp.MustCompile(`^(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`),
+	"version-date":   regexp.MustCompile(`^\d{4}-\d{2}-\d{2}(?:\.\d+)*$`),
+	"version":        regexp.MustCompile(`^\d+(?:\.\d+)*(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`),
+	"version-string": regexp.MustCompile(`^[^#]+$`),
+}
+
+type (
+	// portUpdate is a port moving from one version of a library to another.
+	portUpdate struct {
+		Name    string
+		From    string
+		To      string
+		Release releaseUpdate
+	}
+
+	// textEdit replaces the text between the offsets.
+	textEdit struct {
+		Start int
+		End   int
+		Text  string
+	}
+
+	// jsonMember is the location of a member of a JSON object. The start is
+	// the offset of the key and the end is the offset following the value.
+	jsonMember struct {
+		Key        string
+		Start      int
+		ValueStart int
+		End        int
+	}
+)
+
+func vcpkgUpdateCmd(settings *vcpkgSettings) *cli.Command {
+	var vcpkgPath string
+
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "update the ports of libraries with newer releases",
+		ArgsUsage: "[<library>...]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "vcpkg-path",
+				Usage:       "path to the vcpkg repository",
+				Value:       ".",
+				Destination: &vcpkgPath,
+			},
+		},
+		Action: func(c context.Context, cmd *cli.Command) error {
+			v, err := openVcpkgRepository(vcpkgPath, settings.Overlays)
+			if err != nil {
+				return err
+			}
+
+			var output io.Writer
+			if settings.Output != "" {
+				output, err = os.Create(settings.Output)
+				if err != nil {
+					return fmt.Errorf("could not open file for writing %s: %w", settings.Output, err)
+				}
+			} else {
+				output = os.Stdout
+			}
+
+			r, err := v.checkLibraries(c, cmd, *settings, cmd.Args().Slice())
+			if err != nil {
+				return err
+			}
+
+			cl := &http.Client{Transport: reqcheck.NewRetryTransport(http.DefaultTransport, reqcheck.DefaultRetryOptions())}
+
+			format := cmd.String("format")
+			results := make([]updateResult, 0, len(r.Upgrade)+len(r.Errors))
+
+			failed := len(r.Errors)
+			for _, release := range r.Upgrade {
+				result := updateResult{
+					Name:    release.Name,
+					Status:  statusUpdated,
+					Current: release.currentString(),
+					Latest:  release.Upgrade,
+				}
+
+				files, err := v.updatePort(c, cl, release, settings.DryRun)
+				if err != nil {
+					logrus.WithError(err).WithField("library", release.Name).Warn("could not update library")
+					failed++
+
+					result.Status = statusError
+					result.Error = err.Error()
+					results = append(results, result)
+					continue
+				}
+
+				var diff bytes.Buffer
+				for _, file := range files {
+					result.Files = append(result.Files, v.relativePath(file.Path))
+
+					if settings.DryRun {
+						if err = writeDiff(&diff, v.relativePath(file.Path), file.Original, file.Updated); err != nil {
+							return fmt.Errorf("could not write diff: %w", err)
+						}
+					}
+				}
+				if settings.DryRun {
+					result.Status = statusUpgrade
+					result.Diff = diff.String()
+				}
+
+				switch {
+				case format != formatText:
+					results = append(results, result)
+				case settings.DryRun:
+					_, err = output.Write(diff.Bytes())
+				default:
+					_, err = fmt.Fprintf(output, "Updated %s from %s to %s\n", release.Name, result.Current, release.Upgrade)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			if format != formatText {
+				for _, failure := range r.Errors {
+					results = append(results, updateResult{Name: failure.Name, Status: statusError, Error: failure.Reason})
+				}
+
+				if err = writeResults(output, format, results); err != nil {
+					return fmt.Errorf("could not write results: %w", err)
+				}
+			}
+
+			if failed > 0 {
+				return cli.Exit(fmt.Sprintf("could not update %d of %d libraries", failed, r.total()), exitCodeLibraryErrors)
+			}
+
+			return nil
+		},
+	}
+}
+
+// updatePort rewrites the manifest and portfile of the port for the release
+// and returns the files changed. When performing a dry run the files are left
+// untouched.
+func (v vcpkgRepository) updatePort(ctx context.Context, cl *http.Client, release releaseUpdate, dryRun bool) ([]fileChange, error) {
+	port, err := v.readVcpkgVersion(release.Name, v.Config.Libraries[release.Name].Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("could not find version for %s: %w", release.Name, err)
+	}
+
+	manifestPath := filepath.Join(port.Path, "vcpkg.json")
+	manifest, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest of %s: %w", release.Name, err)
+	}
+
+	version := portVersionText(port.Field, port.Scheme, release)
+
+	updatedManifest, from, err := updateManifest(manifest, port.Field, version)
+	if err != nil {
+		return nil, fmt.Errorf("could not update manifest of %s: %w", release.Name, err)
+	}
+
+	portfilePath := filepath.Join(port.Path, "portfile.cmake")
+	portfile, err := os.ReadFile(portfilePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read portfile of %s: %w", release.Name, err)
+	}
+
+	updatedPortfile, err := updatePortfile(ctx, cl, portfile, portUpdate{
+		Name:    release.Name,
+		From:    from,
+		To:      version,
+		Release: release,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not update portfile of %s: %w", release.Name, err)
+	}
+
+	files := []fileChange{
+		{Path: manifestPath, Original: manifest, Updated: updatedManifest},
+		{Path: portfilePath, Original: portfile, Updated: updatedPortfile},
+	}
+
+	// Record the new version within the registry
+	versions, err := updateVersionDatabase(port.Path, release.Name, port.Field, version, files)
+	if err != nil {
+		return nil, fmt.Errorf("could not update version database: %w", err)
+	}
+	files = append(files, versions...)
+
+	if dryRun {
+		return files, nil
+	}
+
+	for _, file := range files {
+		if err = os.MkdirAll(filepath.Dir(file.Path), 0o755); err != nil {
+			return nil, fmt.Errorf("could not create directory for %s: %w", file.Path, err)
+		}
+		if err = os.WriteFile(file.Path, file.Updated, 0o644); err != nil {
+			return nil, fmt.Errorf("could not write %s: %w", file.Path, err)
+		}
+	}
+
+	return files, nil
+}
+
+// portVersionText is the version written to the port. The version as written
+// upstream is kept when the field accepts it, otherwise the normalized version
+// is written.
+func portVersionText(field string, scheme reqcheck.Scheme, release releaseUpdate) string {
+	matcher, ok := vcpkgFieldMatchers[field]
+	if !ok || !matcher.MatchString(release.UpgradeText) {
+		return release.Upgrade
+	}
+
+	// The text must be the same version as the upgrade
+	text, err := scheme.Parse(release.UpgradeText)
+	if err != nil {
+		return release.Upgrade
+	}
+	upgrade, err := scheme.Parse(release.Upgrade)
+	if err != nil || text.Compare(upgrade) != 0 {
+		return release.Upgrade
+	}
+
+	return release.UpgradeText
+}
+
+// relativePath displays the path relative to the vcpkg repository when it is
+// within it.
+func (v vcpkgRepository) relativePath(p string) string {
+	rel, err := filepath.Rel(v.Path, p)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(p)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// updateManifest sets the version field of a port manifest and removes the
+// port version. Only the members changed are rewritten so the formatting is
+// preserved. The previous version is returned.
+func updateManifest(manifest []byte, field, version string) ([]byte, string, error) {
+	members, err := readJSONMembers(manifest)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var from string
+	var edits []textEdit
+
+	for i, member := range members {
+		switch member.Key {
+		case field:
+			if err = json.Unmarshal(manifest[member.ValueStart:member.End], &from); err != nil {
+				return nil, "", fmt.Errorf("could not read %s: %w", field, err)
+			}
+
+			value, err := json.Marshal(version)
+			if err != nil {
+				return nil, "", err
+			}
+
+			edits = append(edits, textEdit{Start: member.ValueStart, End: member.End, Text: string(value)})
+		case "port-version":
+			// Remove the separator along with the member
+			if i > 0 {
+				edits = append(edits, textEdit{Start: members[i-1].End, End: member.End})
+			} else if len(members) > 1 {
+				edits = append(edits, textEdit{Start: member.Start, End: members[i+1].Start})
+			} else {
+				edits = append(edits, textEdit{Start: member.Start, End: member.End})
+			}
+		}
+	}
+
+	if from == "" {
+		return nil, "", fmt.Errorf("could not find %s: %w", field, ErrCli)
+	}
+
+	return applyEdits(manifest, edits), from, nil
+}
+
+// readJSONMembers locates the members of the top level object.
+func readJSONMembers(data []byte) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected an object: %w", ErrCli)
+	}
+
+	var members []jsonMember
+	for dec.More() {
+		offset := int(dec.InputOffset())
+
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		end := int(dec.InputOffset())
+
+		members = append(members, jsonMember{
+			Key:        key,
+			Start:      offset + bytes.IndexByte(data[offset:], '"'),
+			ValueStart: end - len(value),
+			End:        end,
+		})
+	}
+
+	return members, nil
+}
+
+// updatePortfile points the downloads of a portfile at the new release and
+// updates their hashes. Downloads which do not change are left untouched.
+func updatePortfile(ctx context.Context, cl *http.Client, portfile []byte, u portUpdate) ([]byte, error) {
+	calls, err := parseCMake(portfile)
+	if err != nil {
+		return nil, err
+	}
+
+	from := map[string]string{"PORT": u.Name, "VERSION": u.From}
+	to := map[string]string{"PORT": u.Name, "VERSION": u.To}
+
+	var edits []textEdit
+	updated := 0
+
+	for _, call := range calls {
+		var callEdits []textEdit
+		var urls []string
+
+		// Commands are case insensitive
+		switch strings.ToLower(call.Name) {
+		case "vcpkg_from_github", "vcpkg_from_gitlab":
+			callEdits, urls, err = updateArchive(call, u.Release, from, to)
+		case "vcpkg_download_distfile":
+			callEdits, urls, err = updateDistfile(call, from, to)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not update %s: %w", call.Name, err)
+		}
+		if len(urls) == 0 {
+			continue
+		}
+
+		sha, ok := call.keyword("SHA512")
+		if !ok {
+			return nil, fmt.Errorf("no SHA512 provided to %s: %w", call.Name, ErrCli)
+		}
+		if strings.Contains(sha.Value, "${") {
+			return nil, fmt.Errorf("could not update SHA512 of %s set by %s: %w", call.Name, sha.Value, ErrCli)
+		}
+
+		sum, err := downloadSHA512(ctx, cl, urls)
+		if err != nil {
+			return nil, fmt.Errorf("could not download archive: %w", err)
+		}
+
+		edits = append(edits, callEdits...)
+		edits = append(edits, textEdit{Start: sha.Start, End: sha.End, Text: formatCMakeArg(sha, sum)})
+		updated++
+	}
+
+	if updated == 0 {
+		return nil, fmt.Errorf("no downloads depend on the version: %w", ErrCli)
+	}
+
+	return applyEdits(portfile, edits), nil
+}
+
+// updateArchive updates the REF of a vcpkg_from_github or vcpkg_from_gitlab
+// call returning the url of the new archive. A REF referencing the version
+// is left as is while a literal tag or commit is replaced.
+func updateArchive(call cmakeCall, release releaseUpdate, from, to map[string]string) ([]textEdit, []string, error) {
+	ref, ok := call.keyword("REF")
+	if !ok {
+		return nil, nil, fmt.Errorf("no REF provided: %w", ErrCli)
+	}
+
+	var edits []textEdit
+
+	updatedRef := ref.Value
+	if !strings.Contains(ref.Value, "${") {
+		updatedRef = release.Tag
+		if commitRegex.MatchString(ref.Value) {
+			// A branch or an abbreviated commit would not pin the archive
+			if !commitRegex.MatchString(release.Commit) {
+				return nil, nil, fmt.Errorf("could not find commit of %s %s: %w", release.Name, release.Upgrade, ErrCli)
+			}
+
+			updatedRef = release.Commit
+		}
+		if updatedRef == "" {
+			return nil, nil, fmt.Errorf("could not determine REF of %s %s: %w", release.Name, release.Upgrade, ErrCli)
+		}
+
+		edits = append(edits, textEdit{Start: ref.Start, End: ref.End, Text: formatCMakeArg(ref, updatedRef)})
+	}
+
+	previous, err := expandCMake(ref.Value, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	updatedRef, err = expandCMake(updatedRef, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	if previous == updatedRef {
+		return nil, nil, nil
+	}
+
+	repoArg, ok := call.keyword("REPO")
+	if !ok {
+		return nil, nil, fmt.Errorf("no REPO provided: %w", ErrCli)
+	}
+	repo, err := expandCMake(repoArg.Value, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var url string
+	if strings.EqualFold(call.Name, "vcpkg_from_github") {
+		host := "https://github.com"
+		if hostArg, ok := call.keyword("GITHUB_HOST"); ok {
+			if host, err = expandCMake(hostArg.Value, to); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		url = fmt.Sprintf("%s/%s/archive/%s.tar.gz", strings.TrimSuffix(host, "/"), repo, updatedRef)
+	} else {
+		hostArg, ok := call.keyword("GITLAB_URL")
+		if !ok {
+			return nil, nil, fmt.Errorf("no GITLAB_URL provided: %w", ErrCli)
+		}
+		host, err := expandCMake(hostArg.Value, to)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		url = fmt.Sprintf("%s/%s/-/archive/%s/%s-%s.tar.gz", strings.TrimSuffix(host, "/"), repo, updatedRef, path.Base(repo), updatedRef)
+	}
+
+	return edits, []string{url}, nil
+}
+
+// updateDistfile replaces the version within the URLS and FILENAME of a
+// vcpkg_download_distfile call returning the urls of the new archive. Only
+// the whole version is replaced so 1.2 is not replaced within 1.2.13.
+func updateDistfile(call cmakeCall, from, to map[string]string) ([]textEdit, []string, error) {
+	urlArgs := call.values("URLS")
+	if len(urlArgs) == 0 {
+		return nil, nil, fmt.Errorf("no URLS provided: %w", ErrCli)
+	}
+
+	args := slices.Clone(urlArgs)
+	if filename, ok := call.keyword("FILENAME"); ok {
+		args = append(args, filename)
+	}
+
+	var edits []textEdit
+	var urls []string
+	changed := false
+
+	for i, arg := range args {
+		updated := arg.Value
+		if !strings.Contains(arg.Value, "${") {
+			updated = replaceVersion(arg.Value, from["VERSION"], to["VERSION"])
+			if updated != arg.Value {
+				edits = append(edits, textEdit{Start: arg.Start, End: arg.End, Text: formatCMakeArg(arg, updated)})
+			}
+		}
+
+		previous, err := expandCMake(arg.Value, from)
+		if err != nil {
+			return nil, nil, err
+		}
+		updated, err = expandCMake(updated, to)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		changed = changed || previous != updated
+		if i < len(urlArgs) {
+			urls = append(urls, updated)
+		}
+	}
+
+	if !changed {
+		return nil, nil, nil
+	}
+
+	return edits, urls, nil
+}
+
+// replaceVersion replaces each occurrence of the version that is not part of
+// a longer number or version.
+func replaceVersion(s, from, to string) string {
+	if from == "" {
+		return s
+	}
+
+	var sb strings.Builder
+	for {
+		i := strings.Index(s, from)
+		if i < 0 {
+			sb.WriteString(s)
+
+			return sb.String()
+		}
+
+		end := i + len(from)
+		if versionContinues(s[:i], true) || versionContinues(s[end:], false) {
+			sb.WriteString(s[:end])
+		} else {
+			sb.WriteString(s[:i])
+			sb.WriteString(to)
+		}
+
+		s = s[end:]
+	}
+}
+
+// versionContinues determines if the text next to a version continues it
+// with a letter or digit, or a separator followed by a digit. A leading v is
+// not part of the version.
+func versionContinues(s string, before bool) bool {
+	if before {
+		s = reverseString(s)
+		if s != "" && (s[0] == 'v' || s[0] == 'V') && (len(s) == 1 || !isAlnum(s[1])) {
+			return false
+		}
+	}
+
+	if s != "" && isAlnum(s[0]) {
+		return true
+	}
+
+	return len(s) > 1 && (s[0] == '.' || s[0] == '_') && isDigit(s[1])
+}
+
+func reverseString(s string) string {
+	b := []byte(s)
+	slices.Reverse(b)
+
+	return string(b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// downloadSHA512 downloads the archive from the first url available and
+// returns its hash.
+func downloadSHA512(ctx context.Context, cl *http.Client, urls []string) (string, error) {
+	var errs []error
+
+	for _, u := range urls {
+		logrus.WithField("url", u).Debug("downloading archive")
+
+		sum, err := downloadSum(ctx, cl, u)
+		if err == nil {
+			return sum, nil
+		}
+
+		errs = append(errs, fmt.Errorf("could not download %s: %w", u, err))
+	}
+
+	return "", errors.Join(errs...)
+}
+
+func downloadSum(ctx context.Context, cl *http.Client, u string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := cl.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s: %w", resp.Status, ErrCli)
+	}
+
+	h := sha512.New()
+	if _, err = io.Copy(h, resp.Body); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// formatCMakeArg writes the value quoting it when the argument was quoted or
+// the value requires it.
+func formatCMakeArg(arg cmakeArg, value string) string {
+	if arg.Quoted || value == "" || strings.ContainsAny(value, " \t\r\n()#\"\\;") {
+		return quoteCMake(value)
+	}
+
+	return value
+}
+
+// applyEdits replaces the text of each edit. The edits must not overlap.
+func applyEdits(src []byte, edits []textEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Start > edits[j].Start
+	})
+
+	updated := bytes.Clone(src)
+	for _, edit := range edits {
+		updated = append(updated[:edit.Start], append([]byte(edit.Text), updated[edit.End:]...)...)
+	}
+
+	return updated
+}
+
+// writeDiff outputs a unified diff of the changes to a file. A file without
+// original contents is being created.
+func writeDiff(w io.Writer, name string, original, updated []byte) error {
+	fromFile := "a/" + name
+	if original == nil {
+		fromFile = "/dev/null"
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(original),
+		B:        diffLines(updated),
+		FromFile: fromFile,
+		ToFile:   "b/" + name,
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, diff)
+
+	return err
+}
+
+// diffLines splits the text into lines which each end in a newline.
+func diffLines(text []byte) []string {
+	lines := strings.SplitAfter(string(text), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n"
+
+	return lines
+}
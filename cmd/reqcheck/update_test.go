@@ -0,0 +1,137 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/WebKitForWindows/reqcheck"
+)
+
+func TestPortVersionText(t *testing.T) {
+	tests := []struct {
+		field    string
+		scheme   string
+		upgrade  string
+		text     string
+		expected string
+	}{
+		// The upstream text is kept where the field accepts it
+		{"version", reqcheck.SchemeSemVer, "1.3.0", "1.3", "1.3"},
+		{"version", reqcheck.SchemeDotted, "1.3", "1.3", "1.3"},
+		{"version-string", reqcheck.SchemeDotted, "8.10.0", "8_10_0", "8_10_0"},
+		{"version-semver", reqcheck.SchemeSemVer, "1.3.0-rc.1", "1.3.0-rc.1", "1.3.0-rc.1"},
+		// Otherwise the normalized version is written
+		{"version-semver", reqcheck.SchemeSemVer, "1.3.0", "1.3", "1.3.0"},
+		{"version", reqcheck.SchemeDotted, "8.10.0", "8_10_0", "8.10.0"},
+		{"version-date", reqcheck.SchemeDate, "2026-01-15", "20260115", "2026-01-15"},
+		{"version", reqcheck.SchemeSemVer, "1.3.0", "", "1.3.0"},
+		{"version", reqcheck.SchemeSemVer, "1.3.0", "1.4", "1.3.0"},
+	}
+
+	for _, test := range tests {
+		scheme, err := reqcheck.SchemeFromName(test.scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		version := portVersionText(test.field, scheme, releaseUpdate{Upgrade: test.upgrade, UpgradeText: test.text})
+		if version != test.expected {
+			t.Errorf("%s %q: expected %s, got %s", test.field, test.text, test.expected, version)
+		}
+	}
+}
+
+func TestUpdateArchiveCommit(t *testing.T) {
+	previous := fmt.Sprintf("%040d", 1)
+	commit := fmt.Sprintf("%040d", 2)
+
+	calls, err := parseCMake([]byte("vcpkg_from_github(\n    OUT_SOURCE_PATH SOURCE_PATH\n    REPO owner/repo\n    REF " + previous + "\n    SHA512 0\n)\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions := map[string]string{"PORT": "repo", "VERSION": "1.0.0"}
+
+	for _, invalid := range []string{"", "main", commit[:12]} {
+		_, _, err = updateArchive(calls[0], releaseUpdate{Name: "repo", Upgrade: "1.1.0", Tag: "v1.1.0", Commit: invalid}, versions, versions)
+		if err == nil {
+			t.Errorf("expected an error for the commit %q", invalid)
+		}
+	}
+
+	edits, urls, err := updateArchive(calls[0], releaseUpdate{Name: "repo", Upgrade: "1.1.0", Tag: "v1.1.0", Commit: commit}, versions, versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edits) != 1 || edits[0].Text != commit {
+		t.Errorf("unexpected edits %+v", edits)
+	}
+	if len(urls) != 1 || !strings.HasSuffix(urls[0], "/owner/repo/archive/"+commit+".tar.gz") {
+		t.Errorf("unexpected urls %v", urls)
+	}
+}
+
+func TestUpdateDistfile(t *testing.T) {
+	calls, err := parseCMake([]byte(`vcpkg_download_distfile(ARCHIVE
+    URLS "https://mirror1.2.example.com/zlib/1.2/zlib-1.2.tar.gz"
+         "https://example.com/zlib-1.2.13/zlib-${VERSION}.tar.gz"
+    FILENAME "zlib-1.2-v11.2.tar.gz"
+    SHA512 0
+)
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := map[string]string{"PORT": "zlib", "VERSION": "1.2"}
+	to := map[string]string{"PORT": "zlib", "VERSION": "1.3"}
+
+	edits, urls, err := updateDistfile(calls[0], from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the whole version is replaced
+	var texts []string
+	for _, edit := range edits {
+		texts = append(texts, edit.Text)
+	}
+	if fmt.Sprint(texts) != `["https://mirror1.2.example.com/zlib/1.3/zlib-1.3.tar.gz" "zlib-1.3-v11.2.tar.gz"]` {
+		t.Errorf("unexpected edits %v", texts)
+	}
+	if fmt.Sprint(urls) != "[https://mirror1.2.example.com/zlib/1.3/zlib-1.3.tar.gz https://example.com/zlib-1.2.13/zlib-1.3.tar.gz]" {
+		t.Errorf("unexpected urls %v", urls)
+	}
+
+	// Nothing changes without the version
+	if edits, urls, err = updateDistfile(calls[0], from, from); err != nil || len(edits) != 0 || len(urls) != 0 {
+		t.Errorf("unexpected update %v %v %v", edits, urls, err)
+	}
+}
+
+func TestReplaceVersion(t *testing.T) {
+	tests := []struct {
+		text     string
+		from     string
+		expected string
+	}{
+		{"zlib-1.2.tar.gz", "1.2", "zlib-9.9.tar.gz"},
+		{"zlib-1.2.13.tar.gz", "1.2", "zlib-1.2.13.tar.gz"},
+		{"zlib-11.2.tar.gz", "1.2", "zlib-11.2.tar.gz"},
+		{"mirror1.2.example.com/1.2a", "1.2", "mirror1.2.example.com/1.2a"},
+		{"v1.2/zlib-1.2-src.zip", "1.2", "v9.9/zlib-9.9-src.zip"},
+		{"curl-8_5_0.tar.xz", "8_5_0", "curl-9.9.tar.xz"},
+		{"zlib.tar.gz", "", "zlib.tar.gz"},
+	}
+
+	for _, test := range tests {
+		if replaced := replaceVersion(test.text, test.from, "9.9"); replaced != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.text, test.expected, replaced)
+		}
+	}
+}
@@ -12,24 +12,34 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 
-	"github.com/Masterminds/semver"
 	"github.com/WebKitForWindows/reqcheck"
-	"github.com/reactivex/rxgo/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v3"
 	"gopkg.in/yaml.v3"
 )
 
+// vcpkgSettings are the flags of the vcpkg command which are shared with its
+// subcommands.
+type vcpkgSettings struct {
+	Output   string
+	Overlays []string
+	Slack    bool
+	Notify   bool
+	DryRun   bool
+	Jobs     int
+	Discover bool
+	Graph    string
+}
+
 func vcpkgCmd() *cli.Command {
-	settings := struct {
-		Output   string
-		Overlays []string
-		Slack    bool
-	}{}
+	settings := vcpkgSettings{}
 
 	return &cli.Command{
 		Name:      "vcpkg",
@@ -48,261 +58,826 @@ func vcpkgCmd() *cli.Command {
 			},
 			&cli.BoolFlag{
 				Name:        "slack",
-				Usage:       "output a slack message",
+				Usage:       "send notifications, or output a slack message when none are configured",
 				Destination: &settings.Slack,
 			},
+			&cli.BoolFlag{
+				Name:        "notify",
+				Usage:       "send the results to the configured notifiers",
+				Destination: &settings.Notify,
+			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "output the notifications, or the changes to the ports when updating, rather than applying them",
+				Destination: &settings.DryRun,
+			},
+			&cli.IntFlag{
+				Name:        "jobs",
+				Usage:       "number of libraries to check concurrently",
+				Destination: &settings.Jobs,
+			},
+			&cli.BoolFlag{
+				Name:        "discover",
+				Usage:       "check every port, finding the upstream of those not configured from their portfile",
+				Destination: &settings.Discover,
+			},
+			&cli.StringFlag{
+				Name:        "graph",
+				Usage:       "output the dependency graph of the ports (dot or mermaid) rather than the results",
+				Destination: &settings.Graph,
+			},
+		},
+		Commands: []*cli.Command{
+			vcpkgUpdateCmd(&settings),
+			vcpkgManifestCmd(&settings),
 		},
 		Action: func(c context.Context, cmd *cli.Command) error {
 			if cmd.NArg() > 1 {
 				return fmt.Errorf("command takes one optional argument <vcpkg-path>: %w", ErrCli)
 			}
+			if err := checkGraphFormat(settings.Graph); err != nil {
+				return err
+			}
 
-			// Determine working directory
-			workingDir, err := os.Getwd()
-			logrus.WithField("working-directory", workingDir).Debug("root")
+			v, err := openVcpkgRepository(cmd.Args().Get(0), settings.Overlays)
 			if err != nil {
-				return fmt.Errorf("could not determine working directory: %w", ErrCli)
+				return err
 			}
 
-			// Determine overlay directories
-			for i, overlay := range settings.Overlays {
-				if !filepath.IsAbs(overlay) {
-					settings.Overlays[i] = filepath.Join(workingDir, overlay)
-				}
-				logrus.WithField(fmt.Sprintf("overlay[%d]", i), settings.Overlays[i]).Debug("path")
+			r, err := v.checkLibraries(c, cmd, settings, nil)
+			if err != nil {
+				return err
 			}
 
-			// Determine vcpkg directory
-			vcpkgPath := cmd.Args().Get(0)
-			if !filepath.IsAbs(vcpkgPath) {
-				vcpkgPath = filepath.Join(workingDir, vcpkgPath)
-			}
+			return writeReport(c, cmd, settings, v.Config, r)
+		},
+	}
+}
 
-			logrus.WithField("vcpkg-path", vcpkgPath).Debug("path")
+// writeReport outputs the results of checking the libraries in the format
+// requested and delivers any notifications.
+func writeReport(c context.Context, cmd *cli.Command, settings vcpkgSettings, cfg config, r report) error {
+	var tmpl string
+	if cfg.Template != "" {
+		tmpl = strings.TrimSpace(cfg.Template)
+	} else {
+		tmpl = defaultTmpl
+	}
+	t, err := template.New("vcpkg").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
 
-			// Parse and verify config
-			cfg, err := loadConfig(filepath.Join(vcpkgPath, configFileName))
-			if err != nil {
-				return fmt.Errorf("could not open config file %s: %w", configFileName, err)
-			}
+	var output io.Writer
+	if settings.Output != "" {
+		output, err = os.Create(settings.Output)
+		if err != nil {
+			return fmt.Errorf("could not open file for writing %s: %w", settings.Output, err)
+		}
+	} else {
+		output = os.Stdout
+	}
 
-			scms := make(map[string]reqcheck.Client)
-			for name, scmConfig := range cfg.Scms {
-				scm, err := reqcheck.NewClientFromDriver(scmConfig.Driver, scmConfig.URI, scmConfig.Token)
-				if err != nil {
-					return fmt.Errorf("could not connect to scm %s: %w", name, err)
-				}
-				scms[name] = scm
-			}
+	// Without any notifiers --slack outputs a message for delivery by
+	// another tool
+	notifiers := cfg.notifiers()
+	notify := settings.Notify || (settings.Slack && len(notifiers) > 0)
+	legacySlack := settings.Slack && len(notifiers) == 0
 
-			var tmpl string
-			if cfg.Template != "" {
-				tmpl = strings.TrimSpace(cfg.Template)
-			} else {
-				tmpl = defaultTmpl
-			}
-			t, err := template.New("vcpkg").Parse(tmpl)
-			if err != nil {
-				return fmt.Errorf("could not parse template: %w", err)
-			}
+	format := cmd.String("format")
 
-			var output io.Writer
-			if settings.Output != "" {
-				output, err = os.Create(settings.Output)
-				if err != nil {
-					return fmt.Errorf("could not open file for writing %s: %w", settings.Output, err)
-				}
-			} else {
-				output = os.Stdout
-			}
+	if notify && settings.DryRun {
+		if err = writeNotifications(output, notifiers, r); err != nil {
+			return err
+		}
+	} else if settings.Graph != "" {
+		if err = writeGraph(output, settings.Graph, r); err != nil {
+			return fmt.Errorf("could not write graph: %w", err)
+		}
+	} else if format != formatText {
+		if legacySlack {
+			return fmt.Errorf("slack output requires the text format: %w", ErrCli)
+		}
 
-			// Iterate over values
-			type releaseUpdate struct {
-				Name    string
-				Current string
-				Upgrade string
-			}
+		// Output results in a structured format
+		results := make([]libraryResult, 0, r.total())
+		for _, release := range r.Current {
+			results = append(results, newLibraryResult(statusCurrent, release))
+		}
+		for _, release := range r.Upgrade {
+			results = append(results, newLibraryResult(statusUpgrade, release))
+		}
+		for _, failure := range r.Errors {
+			results = append(results, newLibraryErrorResult(failure))
+		}
 
-			current := make([]releaseUpdate, 0)
-			upgrade := make([]releaseUpdate, 0)
+		if err = writeResults(output, format, results); err != nil {
+			return fmt.Errorf("could not write results: %w", err)
+		}
+	} else if err = writeTemplate(output, t, legacySlack, r); err != nil {
+		return err
+	}
 
-			for name, library := range cfg.Libraries {
-				semVersion, err := readVcpkgVersion(settings.Overlays, vcpkgPath, name)
-				if err != nil {
-					return fmt.Errorf("could not find version for %s: %w", name, err)
-				}
-				version := semVersion.String()
+	if notify && !settings.DryRun {
+		if err = sendNotifications(c, notifiers, r); err != nil {
+			return fmt.Errorf("could not deliver notifications: %w", err)
+		}
+	}
 
-				logrus.WithField("version", version).Debug("found config")
+	if len(r.Errors) > 0 {
+		return cli.Exit(fmt.Sprintf("could not check %d of %d libraries", len(r.Errors), r.total()), exitCodeLibraryErrors)
+	}
 
-				var constraintFmt string
-				if library.Constraint != "" {
-					constraintFmt = library.Constraint
-				} else {
-					constraintFmt = ">= %s"
-				}
+	return nil
+}
 
-				constraintStr := fmt.Sprintf(constraintFmt, version)
-				constraint, err := semver.NewConstraint(constraintStr)
-				if err != nil {
-					return fmt.Errorf("could not create constraint for %s from %s: %w", name, version, err)
-				}
-				logrus.WithField("constraint", constraintStr).Debug("found constraint")
+// writeTemplate outputs the results using the template, optionally wrapped
+// in a slack message.
+func writeTemplate(output io.Writer, t *template.Template, slack bool, r report) error {
+	buffer := bytes.NewBuffer([]byte{})
+	var writeTemplateTo io.Writer
+	if slack {
+		writeTemplateTo = buffer
+	} else {
+		writeTemplateTo = output
+	}
 
-				scm, ok := scms[library.Host]
-				if !ok {
-					return fmt.Errorf("could not find scm assigned to %s: %w", library.Host, ErrCli)
-				}
+	err := t.Execute(writeTemplateTo, r)
+	if err != nil {
+		return fmt.Errorf("could not write results: %w", err)
+	}
 
-				releaseOpts := reqcheck.ListReleaseOptions{
-					Owner:   library.Owner,
-					Repo:    library.Repo,
-					Tags:    library.Tags,
-					LimitTo: library.LimitTo,
-				}
+	if slack {
+		payload := map[string]interface{}{
+			"channel": "${{ env.SLACK_CHANNEL_ID }}",
+			"text":    "Requirements check",
+			"blocks": []map[string]interface{}{{
+				"type": "section",
+				"text": map[string]interface{}{
+					"type": "mrkdwn",
+					"text": buffer.String(),
+				},
+			}},
+		}
 
-				latestRelease, err := reqcheck.ListReleases(scm, releaseOpts).
-					Filter(reqcheck.FilterSemanticConstraint(constraint)).
-					Reduce(reqcheck.ReduceGreatestVersion).
-					Get()
-				if err != nil || latestRelease == rxgo.OptionalSingleEmpty {
-					return fmt.Errorf("could not get releases %w", err)
-				}
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("could not create slack payload: %w", err)
+		}
 
-				release := releaseUpdate{
-					Name:    name,
-					Current: version,
-					Upgrade: latestRelease.V.(reqcheck.Release).SemVer.String(),
-				}
+		_, err = output.Write(encoded)
+		if err != nil {
+			return fmt.Errorf("could not write results: %w", err)
+		}
+	}
+
+	return nil
+}
 
-				if release.Current == release.Upgrade {
-					current = append(current, release)
-				} else {
-					upgrade = append(upgrade, release)
+// jobsDefault is the number of libraries checked concurrently when not
+// configured.
+const jobsDefault = 4
+
+// exitCodeLibraryErrors is returned when the results are complete other than
+// the libraries that could not be checked.
+const exitCodeLibraryErrors = 2
+
+type (
+	releaseUpdate struct {
+		Name        string
+		Host        string
+		Owner       string
+		Repo        string
+		Current     string
+		PortVersion int
+		Upgrade     string
+		// UpgradeText is the upgrade as written upstream, such as 1.3 rather
+		// than the semantic version 1.3.0.
+		UpgradeText string
+		Tag         string
+		Commit      string
+		URL         string
+		PublishedAt time.Time
+		Draft       bool
+		Prerelease  bool
+		Baseline    string
+		// BaselineMismatch is set when the baseline of the registry differs
+		// from the port.
+		BaselineMismatch bool
+		// Dependents are the ports depending directly on an upgrade.
+		Dependents []string
+	}
+
+	releaseError struct {
+		Name   string
+		Host   string
+		Owner  string
+		Repo   string
+		Reason string
+	}
+
+	// report is the outcome of checking the libraries.
+	report struct {
+		Current []releaseUpdate
+		Upgrade []releaseUpdate
+		Errors  []releaseError
+
+		graph dependencyGraph
+	}
+)
+
+// currentString is the current version including any port version.
+func (r releaseUpdate) currentString() string {
+	if r.PortVersion > 0 {
+		return fmt.Sprintf("%s#%d", r.Current, r.PortVersion)
+	}
+
+	return r.Current
+}
+
+// summary counts the libraries in each state.
+func (r report) summary() string {
+	s := fmt.Sprintf("%d up to date, %d with updates", len(r.Current), len(r.Upgrade))
+	if len(r.Errors) > 0 {
+		s += fmt.Sprintf(", %d could not be checked", len(r.Errors))
+	}
+
+	return s
+}
+
+// vcpkgRepository is a vcpkg checkout along with its config. When checking
+// the manifest of a project the versions come from the manifest instead of
+// the ports.
+type vcpkgRepository struct {
+	Path     string
+	Overlays []string
+	Config   config
+	Manifest *projectManifest
+}
+
+// openVcpkgRepository resolves the paths relative to the working directory and
+// loads the config of the vcpkg repository.
+func openVcpkgRepository(vcpkgPath string, overlays []string) (vcpkgRepository, error) {
+	// Determine working directory
+	workingDir, err := os.Getwd()
+	logrus.WithField("working-directory", workingDir).Debug("root")
+	if err != nil {
+		return vcpkgRepository{}, fmt.Errorf("could not determine working directory: %w", ErrCli)
+	}
+
+	// Determine overlay directories
+	overlayPaths := make([]string, len(overlays))
+	for i, overlay := range overlays {
+		if !filepath.IsAbs(overlay) {
+			overlay = filepath.Join(workingDir, overlay)
+		}
+		overlayPaths[i] = overlay
+		logrus.WithField(fmt.Sprintf("overlay[%d]", i), overlay).Debug("path")
+	}
+
+	// Determine vcpkg directory
+	if !filepath.IsAbs(vcpkgPath) {
+		vcpkgPath = filepath.Join(workingDir, vcpkgPath)
+	}
+
+	logrus.WithField("vcpkg-path", vcpkgPath).Debug("path")
+
+	// Parse and verify config
+	cfg, err := loadConfig(filepath.Join(vcpkgPath, configFileName))
+	if err != nil {
+		return vcpkgRepository{}, fmt.Errorf("could not open config file %s: %w", configFileName, err)
+	}
+
+	return vcpkgRepository{Path: vcpkgPath, Overlays: overlayPaths, Config: cfg}, nil
+}
+
+// checkLibraries checks the named libraries, or every library when no names
+// are given, and sorts the results by name with upgrades following those of
+// their dependencies. When discovering, every port without a library
+// configured is checked as well.
+func (v vcpkgRepository) checkLibraries(ctx context.Context, cmd *cli.Command, settings vcpkgSettings, names []string) (report, error) {
+	cfg := v.Config
+
+	// Determine the libraries to check
+	var discovered []string
+	if len(names) == 0 {
+		for name := range cfg.Libraries {
+			names = append(names, name)
+		}
+
+		if settings.Discover {
+			ports, err := v.portNames()
+			if err != nil {
+				return report{}, err
+			}
+
+			for _, port := range ports {
+				if _, ok := cfg.Libraries[port]; !ok {
+					discovered = append(discovered, port)
 				}
 			}
+		}
+	}
 
-			// Sort the results
-			sort.Slice(current, func(i, j int) bool {
-				return current[i].Name < current[j].Name
-			})
-			sort.Slice(upgrade, func(i, j int) bool {
-				return upgrade[i].Name < upgrade[j].Name
+	// Find the upstream of any library which does not configure it
+	libraries := make(map[string]library, len(names)+len(discovered))
+	failed := make([]releaseError, 0)
+
+	for _, name := range names {
+		library, err := v.resolveLibrary(name, cfg.Libraries[name])
+		if err != nil {
+			logrus.WithError(err).WithField("library", name).Warn("could not check library")
+
+			failed = append(failed, releaseError{
+				Name:   name,
+				Host:   library.Host,
+				Owner:  library.Owner,
+				Repo:   library.Repo,
+				Reason: err.Error(),
 			})
+			continue
+		}
+		libraries[name] = library
+	}
+
+	// Ports which could not be checked are expected when discovering
+	for _, name := range discovered {
+		library, err := v.resolveLibrary(name, library{})
+		if err != nil {
+			logrus.WithError(err).WithField("port", name).Debug("skipping port")
+			continue
+		}
+		libraries[name] = library
+	}
 
-			// Output results to template
-			td := struct {
-				Current []releaseUpdate
-				Upgrade []releaseUpdate
-			}{
-				Current: current,
-				Upgrade: upgrade,
+	cache := resolveCache(cmd, cfg, v.Path)
+
+	scms := make(map[string]reqcheck.Client)
+	for name, scmConfig := range cfg.Scms {
+		scm, err := reqcheck.NewClientFromDriverWithOptions(scmConfig.Driver, scmConfig.URI, scmConfig.Token, reqcheck.ClientOptions{
+			Retry:   scmConfig.Retry,
+			Cache:   cache,
+			Options: scmConfig.Options,
+		})
+		if err != nil {
+			return report{}, fmt.Errorf("could not connect to scm %s: %w", name, err)
+		}
+		scms[name] = scm
+	}
+
+	// Retrieve the first page of libraries in batches when supported
+	prefetch := make(map[string][]reqcheck.Repository)
+	for _, library := range libraries {
+		prefetch[library.Host] = append(prefetch[library.Host], reqcheck.Repository{
+			Owner: library.Owner,
+			Name:  library.Repo,
+			Tags:  library.Tags,
+		})
+	}
+	for name, repos := range prefetch {
+		if prefetcher, ok := scms[name].(reqcheck.Prefetcher); ok {
+			if err := prefetcher.Prefetch(ctx, repos); err != nil {
+				logrus.WithError(err).WithField("scm", name).Warn("could not prefetch releases")
 			}
+		}
+	}
 
-			buffer := bytes.NewBuffer([]byte{})
-			var writeTemplateTo io.Writer
-			if settings.Slack {
-				writeTemplateTo = buffer
-			} else {
-				writeTemplateTo = output
+	// Determine concurrency
+	jobs := settings.Jobs
+	if jobs <= 0 {
+		jobs = cfg.Concurrency
+	}
+	if jobs <= 0 {
+		jobs = jobsDefault
+	}
+
+	logrus.WithField("jobs", jobs).Debug("concurrency")
+
+	// Limit the number of concurrent checks overall and for each scm
+	jobSem := make(chan struct{}, jobs)
+	scmSems := make(map[string]chan struct{})
+	for name, scmConfig := range cfg.Scms {
+		if scmConfig.Concurrency > 0 {
+			scmSems[name] = make(chan struct{}, scmConfig.Concurrency)
+		}
+	}
+
+	// Check each library collecting any failures
+	current := make([]releaseUpdate, 0)
+	upgrade := make([]releaseUpdate, 0)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, library := range libraries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Acquire the scm first so waiting does not hold up other scms
+			if scmSem, ok := scmSems[library.Host]; ok {
+				scmSem <- struct{}{}
+				defer func() { <-scmSem }()
 			}
 
-			err = t.Execute(writeTemplateTo, td)
+			jobSem <- struct{}{}
+			defer func() { <-jobSem }()
+
+			release, upToDate, err := v.checkLibrary(ctx, scms, name, library)
+
+			mu.Lock()
+			defer mu.Unlock()
+
 			if err != nil {
-				return fmt.Errorf("could not write results: %w", err)
+				logrus.WithError(err).WithField("library", name).Warn("could not check library")
+
+				failed = append(failed, releaseError{
+					Name:   name,
+					Host:   library.Host,
+					Owner:  library.Owner,
+					Repo:   library.Repo,
+					Reason: err.Error(),
+				})
+			} else if upToDate {
+				current = append(current, release)
+			} else {
+				upgrade = append(upgrade, release)
 			}
+		}()
+	}
 
-			if settings.Slack {
-				payload := map[string]interface{}{
-					"channel": "${{ env.SLACK_CHANNEL_ID }}",
-					"text":    "Requirements check",
-					"blocks": []map[string]interface{}{{
-						"type": "section",
-						"text": map[string]interface{}{
-							"type": "mrkdwn",
-							"text": buffer.String(),
-						},
-					}},
-				}
+	wg.Wait()
 
-				encoded, err := json.Marshal(payload)
-				if err != nil {
-					return fmt.Errorf("could not create slack payload: %w", err)
-				}
+	// Sort the results
+	sort.Slice(current, func(i, j int) bool {
+		return current[i].Name < current[j].Name
+	})
+	sort.Slice(upgrade, func(i, j int) bool {
+		return upgrade[i].Name < upgrade[j].Name
+	})
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].Name < failed[j].Name
+	})
 
-				_, err = output.Write(encoded)
-				if err != nil {
-					return fmt.Errorf("could not write results: %w", err)
-				}
-			}
+	r := report{Current: current, Upgrade: upgrade, Errors: failed}
 
-			return nil
-		},
+	checked := make([]string, 0, r.total())
+	for _, release := range append(append([]releaseUpdate{}, r.Current...), r.Upgrade...) {
+		checked = append(checked, release.Name)
+	}
+	for _, failure := range r.Errors {
+		checked = append(checked, failure.Name)
+	}
+
+	// Ports which were not checked may depend on an upgrade
+	var all dependencyGraph
+	if len(r.Upgrade) > 0 {
+		ports, err := v.portNames()
+		if err != nil {
+			return report{}, err
+		}
+
+		all = v.readDependencyGraph(ports)
 	}
+
+	r.orderByDependencies(v.readDependencyGraph(checked), all)
+
+	return r, nil
 }
 
-const configFileName = ".reqcheck.yml"
+// total is the number of libraries checked.
+func (r report) total() int {
+	return len(r.Current) + len(r.Upgrade) + len(r.Errors)
+}
 
-func readVcpkgVersion(overlayPaths []string, vcpkgPath, name string) (*semver.Version, error) {
-	var file []byte
+// checkLibrary determines the latest release of a library and whether the
+// port is up to date with it.
+func (v vcpkgRepository) checkLibrary(ctx context.Context, scms map[string]reqcheck.Client, name string, library library) (releaseUpdate, bool, error) {
+	var port vcpkgVersion
 	var err error
-	overlayPaths = append(overlayPaths, vcpkgPath)
+	if v.Manifest != nil {
+		port, err = v.pinnedVersion(name, library.Scheme)
+	} else {
+		port, err = v.readVcpkgVersion(name, library.Scheme)
+	}
+	if err != nil {
+		return releaseUpdate{}, false, fmt.Errorf("could not find version for %s: %w", name, err)
+	}
+	version := port.Version.String()
+
+	logrus.WithFields(logrus.Fields{
+		"library":      name,
+		"version":      version,
+		"port-version": port.PortVersion,
+		"field":        port.Field,
+		"scheme":       port.Scheme.Name(),
+	}).Debug("found config")
+
+	// A project is expected to differ from the baseline
+	var baseline baselineVersion
+	var hasBaseline bool
+	if v.Manifest == nil {
+		baseline, hasBaseline, err = readBaseline(port.Path, name)
+		if err != nil {
+			return releaseUpdate{}, false, fmt.Errorf("could not read baseline for %s: %w", name, err)
+		}
+	}
 
-	for _, path := range overlayPaths {
-		file, err = os.ReadFile(filepath.Join(path, "ports", name, "vcpkg.json"))
-		if err == nil {
-			break
+	var baselineMismatch bool
+	if hasBaseline {
+		baselineVersion, err := port.Scheme.Parse(baseline.Baseline)
+		baselineMismatch = err != nil || baselineVersion.Compare(port.Version) != 0 || baseline.PortVersion != port.PortVersion
+
+		if baselineMismatch {
+			logrus.WithFields(logrus.Fields{
+				"library":  name,
+				"version":  version,
+				"baseline": baseline.String(),
+			}).Warn("port does not match the baseline")
+		}
+	}
+
+	var constraintFmt string
+	if library.Constraint != "" {
+		constraintFmt = library.Constraint
+	} else {
+		constraintFmt = ">= %s"
+	}
+
+	constraintStr := fmt.Sprintf(constraintFmt, version)
+	constraint, err := reqcheck.NewConstraint(port.Scheme, constraintStr)
+	if err != nil {
+		return releaseUpdate{}, false, fmt.Errorf("could not create constraint for %s from %s: %w", name, version, err)
+	}
+	logrus.WithFields(logrus.Fields{
+		"library":    name,
+		"constraint": constraintStr,
+	}).Debug("found constraint")
+
+	scm, ok := scms[library.Host]
+	if !ok {
+		return releaseUpdate{}, false, fmt.Errorf("could not find scm assigned to %s: %w", library.Host, ErrCli)
+	}
+
+	releaseOpts := reqcheck.ListReleaseOptions{
+		Owner:              library.Owner,
+		Repo:               library.Repo,
+		Tags:               library.Tags,
+		LimitTo:            library.LimitTo,
+		ExcludeDrafts:      !library.Draft,
+		ExcludePrereleases: !library.Prerelease,
+		Scheme:             port.Scheme,
+		Context:            ctx,
+	}
+
+	// Releases older than the port are not of interest
+	if library.StopBelowCurrent {
+		releaseOpts.StopBelow = port.Version
+	}
+
+	if library.Pattern != "" || library.Prefix != "" || library.Suffix != "" || len(library.Exclude) > 0 {
+		releaseOpts.Version, err = reqcheck.NewVersionPattern(library.Pattern, library.Prefix, library.Suffix, library.Exclude)
+		if err != nil {
+			return releaseUpdate{}, false, fmt.Errorf("could not create version pattern for %s: %w", name, err)
 		}
 	}
 
-	if file == nil {
-		return nil, fmt.Errorf("could not find config file for %s: %w", name, os.ErrNotExist)
+	releases := reqcheck.Filter(reqcheck.Releases(scm, releaseOpts), reqcheck.And(reqcheck.HasVersion, reqcheck.SatisfiesConstraint(constraint)))
+
+	latest, ok, err := reqcheck.GreatestVersion(releases)
+	if err != nil {
+		return releaseUpdate{}, false, fmt.Errorf("could not get releases for %s: %w", name, err)
+	}
+	if !ok || latest.Version == nil {
+		return releaseUpdate{}, false, fmt.Errorf("no release of %s satisfies %s: %w", name, constraintStr, ErrCli)
+	}
+
+	release := releaseUpdate{
+		Name:        name,
+		Host:        library.Host,
+		Owner:       library.Owner,
+		Repo:        library.Repo,
+		Current:     version,
+		PortVersion: port.PortVersion,
+		Upgrade:     latest.Version.String(),
+		UpgradeText: reqcheck.ReleaseVersionText(latest, releaseOpts.Version),
+		Tag:         latest.Tag,
+		Commit:      latest.Commit,
+		URL:         latest.URL,
+		PublishedAt: latest.PublishedAt,
+		Draft:       latest.Draft,
+		Prerelease:  latest.Prerelease,
+	}
+	if hasBaseline {
+		release.Baseline = baseline.String()
+		release.BaselineMismatch = baselineMismatch
+	}
+
+	current := port.Version.Compare(latest.Version) == 0
+
+	// Only the commit of an upgrade is needed so it is looked up when missing
+	if resolver, ok := scm.(reqcheck.TagResolver); ok && !current && release.Commit == "" {
+		commit, err := resolver.ResolveTag(ctx, library.Owner, library.Repo, latest.Tag)
+		if err != nil {
+			logrus.WithError(err).WithField("library", name).Warn("could not find commit of release")
+		}
+		release.Commit = commit
+	}
+
+	return release, current, nil
+}
+
+const configFileName = ".reqcheck.yml"
+
+// vcpkgVersion is the version of a port.
+type vcpkgVersion struct {
+	// Version of the upstream library.
+	Version reqcheck.Version
+	// Scheme the version should be compared with.
+	Scheme reqcheck.Scheme
+	// Field the version was read from.
+	Field string
+	// PortVersion is incremented when the port changes but the upstream
+	// library does not.
+	PortVersion int
+	// Path to the directory containing the port.
+	Path string
+}
+
+// readVcpkgVersion reads the version of the port along with the scheme it
+// should be compared with. The scheme is determined by the version field used
+// unless the library specifies one.
+func (v vcpkgRepository) readVcpkgVersion(name, schemeName string) (vcpkgVersion, error) {
+	portPath, file, err := v.findPort(name)
+	if err != nil {
+		return vcpkgVersion{}, err
 	}
 
 	un := make(map[interface{}]interface{})
 	err = yaml.Unmarshal(file, &un)
 	if err != nil {
-		return nil, fmt.Errorf("could not read %s config file: %w", name, err)
+		return vcpkgVersion{}, fmt.Errorf("could not read %s config file: %w", name, err)
+	}
+
+	for _, field := range vcpkgVersionFields {
+		ver, ok := un[field.Name].(string)
+		if !ok {
+			continue
+		}
+
+		version, scheme, err := parseVersionField(name, field.Name, ver, schemeName)
+		if err != nil {
+			return vcpkgVersion{}, err
+		}
+
+		portVersion, _ := un["port-version"].(int)
+
+		return vcpkgVersion{
+			Version:     version,
+			Scheme:      scheme,
+			Field:       field.Name,
+			PortVersion: portVersion,
+			Path:        portPath,
+		}, nil
+	}
+
+	return vcpkgVersion{}, fmt.Errorf("could not find version string for %s: %w", name, ErrCli)
+}
+
+// parseVersionField parses the value of a version field. The scheme is
+// determined by the field unless one is given.
+func parseVersionField(name, field, value, schemeName string) (reqcheck.Version, reqcheck.Scheme, error) {
+	if schemeName == "" {
+		for _, f := range vcpkgVersionFields {
+			if f.Name == field {
+				schemeName = f.Scheme
+			}
+		}
+
+		// A relaxed version may have more components than a semantic version
+		if field == "version" && relaxedVersionMatcher.MatchString(value) {
+			schemeName = reqcheck.SchemeDotted
+		}
+	}
+
+	scheme, err := reqcheck.SchemeFromName(schemeName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not determine version scheme for %s: %w", name, err)
 	}
 
-	if semVer, ok := un["version-semver"].(string); ok {
-		return semver.NewVersion(semVer)
+	version, err := scheme.Parse(value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse %s %s for %s: %w", field, value, name, err)
+	}
+
+	return version, scheme, nil
+}
+
+// portPaths are the directories containing ports in order of precedence.
+// The overlays come first followed by the ports of the vcpkg repository or,
+// for a project, those of its registries.
+func (v vcpkgRepository) portPaths() []string {
+	var paths []string
+	for _, overlay := range v.Overlays {
+		paths = append(paths, filepath.Join(overlay, "ports"))
 	}
 
-	if ver, ok := un["version"].(string); ok {
-		return semver.NewVersion(ver)
+	if v.Manifest != nil {
+		return append(paths, v.Manifest.PortPaths...)
 	}
 
-	return nil, fmt.Errorf("could not find version string for %s: %w", name, ErrCli)
+	return append(paths, filepath.Join(v.Path, "ports"))
 }
 
+// findPort locates a port returning the path to the port and its manifest.
+func (v vcpkgRepository) findPort(name string) (string, []byte, error) {
+	for _, path := range v.portPaths() {
+		portPath := filepath.Join(path, name)
+
+		// An overlay may be the port itself
+		if filepath.Base(path) == name {
+			if _, err := os.Stat(filepath.Join(path, "vcpkg.json")); err == nil {
+				portPath = path
+			}
+		}
+
+		file, err := os.ReadFile(filepath.Join(portPath, "vcpkg.json"))
+		if err == nil {
+			return portPath, file, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("could not find config file for %s: %w", name, os.ErrNotExist)
+}
+
+// vcpkgVersionFields maps the version fields of a port onto the scheme used
+// when the library does not specify one.
+var vcpkgVersionFields = []struct {
+	Name   string
+	Scheme string
+}{
+	{Name: "version-semver", Scheme: reqcheck.SchemeSemVer},
+	{Name: "version-date", Scheme: reqcheck.SchemeDate},
+	{Name: "version", Scheme: reqcheck.SchemeSemVer},
+	{Name: "version-string", Scheme: reqcheck.SchemeDotted},
+}
+
+// relaxedVersionMatcher matches a relaxed version that is not a semantic
+// version, such as 1.2.3.4.
+var relaxedVersionMatcher = regexp.MustCompile(`^\d+(?:\.\d+){3,}$`)
+
 const defaultTmpl = `The following libraries are up to date:
-{{ range .Current }}  {{ .Name }}: {{ .Current }}
+{{ range .Current }}  {{ .Name }}: {{ .Current }}{{ if .PortVersion }}#{{ .PortVersion }}{{ end }}{{ if .BaselineMismatch }} (baseline {{ .Baseline }}){{ end }}
 {{ else }}  No libraries are up to date{{ end }}
 The following libraries have updates:
-{{ range .Upgrade}}  {{ .Name }}: {{ .Current }} -> {{ .Upgrade }}
-{{ else }}  All libraries are up to date{{ end }}`
+{{ range .Upgrade}}  {{ .Name }}: {{ .Current }}{{ if .PortVersion }}#{{ .PortVersion }}{{ end }}{{ if .BaselineMismatch }} (baseline {{ .Baseline }}){{ end }} -> {{ .Upgrade }}{{ if not .PublishedAt.IsZero }} (published {{ .PublishedAt.Format "2006-01-02" }}){{ end }}{{ if .Dependents }} (used by {{ range $i, $name := .Dependents }}{{ if $i }}, {{ end }}{{ $name }}{{ end }}){{ end }}
+{{ else }}  All libraries are up to date{{ end }}
+{{- if .Errors }}
+The following libraries could not be checked:
+{{ range .Errors }}  {{ .Name }}: {{ .Reason }}
+{{ end }}{{ end }}`
 
 type (
 	config struct {
-		Scms      map[string]sourceControl `yaml:"scm"`
-		Libraries map[string]library       `yaml:"repos"`
-		Template  string                   `yaml:"template"`
+		Scms        map[string]sourceControl `yaml:"scm"`
+		Libraries   map[string]library       `yaml:"repos"`
+		Template    string                   `yaml:"template"`
+		Concurrency int                      `yaml:"concurrency"`
+		Cache       cacheConfig              `yaml:"cache"`
+		Slack       slackConfig              `yaml:"slack"`
+		Notify      []notifierConfig         `yaml:"notify"`
+	}
+
+	cacheConfig struct {
+		Dir string        `yaml:"dir"`
+		TTL time.Duration `yaml:"ttl"`
 	}
 
 	sourceControl struct {
-		Driver string
-		URI    string
-		Token  string
+		Driver      string
+		URI         string
+		Token       string
+		Concurrency int
+		Retry       *reqcheck.RetryOptions
+		Options     map[string]interface{}
 	}
 
 	library struct {
-		Host       string `yaml:"host"`
-		Owner      string `yaml:"owner"`
-		Repo       string `yaml:"repo"`
-		Tags       bool   `yaml:"tags"`
-		Constraint string `yaml:"constraint"`
-		LimitTo    int    `yaml:"limit"`
+		Host             string   `yaml:"host"`
+		Owner            string   `yaml:"owner"`
+		Repo             string   `yaml:"repo"`
+		Tags             bool     `yaml:"tags"`
+		Constraint       string   `yaml:"constraint"`
+		LimitTo          int      `yaml:"limit"`
+		Draft            bool     `yaml:"draft"`
+		Prerelease       bool     `yaml:"prerelease"`
+		Pattern          string   `yaml:"pattern"`
+		Prefix           string   `yaml:"prefix"`
+		Suffix           string   `yaml:"suffix"`
+		Exclude          []string `yaml:"exclude"`
+		Scheme           string   `yaml:"scheme"`
+		StopBelowCurrent bool     `yaml:"stop_below_current"`
 	}
 )
 
@@ -340,23 +915,105 @@ func (s *sourceControl) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		s.URI = val.(string)
 	}
 
-	val, ok = un["token"]
+	val, ok = un["concurrency"]
 	if ok {
-		switch t := val.(type) {
-		case string:
-			s.Token = t
-		case map[string]interface{}:
-			envVar, ok := t["from_environment"].(string)
-			if ok {
-				env, ok := os.LookupEnv(envVar)
-				if ok {
-					s.Token = env
-				} else {
-					return fmt.Errorf("could not find token in %s, %w", envVar, ErrCli)
-				}
-			}
+		s.Concurrency, ok = val.(int)
+		if !ok {
+			return fmt.Errorf("invalid concurrency %v: %w", val, ErrCli)
+		}
+	}
+
+	val, ok = un["retry"]
+	if ok {
+		s.Retry, err = parseRetryOptions(val)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.Token, err = parseSecret(un["token"])
+	if err != nil {
+		return err
+	}
+
+	// Any other settings are specific to the driver
+	s.Options = make(map[string]interface{})
+	for key, val := range un {
+		name, ok := key.(string)
+		if !ok {
+			continue
+		}
+
+		switch name {
+		case "driver", "uri", "token", "concurrency", "retry":
+		default:
+			s.Options[name] = val
 		}
 	}
 
 	return nil
 }
+
+// parseSecret reads a value that is either given directly or, using
+// from_environment, read from an environment variable.
+func parseSecret(val interface{}) (string, error) {
+	switch t := val.(type) {
+	case string:
+		return t, nil
+	case map[string]interface{}:
+		envVar, ok := t["from_environment"].(string)
+		if ok {
+			env, ok := os.LookupEnv(envVar)
+			if !ok {
+				return "", fmt.Errorf("could not find environment variable %s: %w", envVar, ErrCli)
+			}
+
+			return env, nil
+		}
+	}
+
+	return "", nil
+}
+
+// parseRetryOptions reads the retry settings of a scm. Retries are disabled
+// with false and any settings not present use the defaults.
+func parseRetryOptions(val interface{}) (*reqcheck.RetryOptions, error) {
+	opts := reqcheck.DefaultRetryOptions()
+
+	switch r := val.(type) {
+	case bool:
+		if !r {
+			opts.MaxRetries = 0
+		}
+	case map[string]interface{}:
+		if retries, ok := r["max_retries"]; ok {
+			n, ok := retries.(int)
+			if !ok || n < 0 {
+				return nil, fmt.Errorf("invalid max_retries %v: %w", retries, ErrCli)
+			}
+			opts.MaxRetries = n
+		}
+
+		durations := map[string]*time.Duration{
+			"max_wait":    &opts.MaxWait,
+			"min_backoff": &opts.MinBackoff,
+			"max_backoff": &opts.MaxBackoff,
+		}
+		for key, dest := range durations {
+			text, ok := r[key]
+			if !ok {
+				continue
+			}
+
+			d, err := time.ParseDuration(fmt.Sprint(text))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %v: %w", key, text, ErrCli)
+			}
+			*dest = d
+		}
+	default:
+		return nil, fmt.Errorf("invalid retry settings %v: %w", val, ErrCli)
+	}
+
+	return &opts, nil
+}
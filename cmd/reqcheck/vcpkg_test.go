@@ -0,0 +1,85 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/WebKitForWindows/reqcheck"
+)
+
+func TestParseVersionField(t *testing.T) {
+	tests := []struct {
+		field    string
+		value    string
+		scheme   string
+		expected string
+	}{
+		{"version", "1.3.1", "", reqcheck.SchemeSemVer},
+		{"version", "1.3", "", reqcheck.SchemeSemVer},
+		{"version", "1.2.13.1", "", reqcheck.SchemeDotted},
+		{"version-semver", "1.3.0-rc.1", "", reqcheck.SchemeSemVer},
+		{"version-date", "2024-01-15", "", reqcheck.SchemeDate},
+		{"version-string", "8_10_0", "", reqcheck.SchemeDotted},
+		// The scheme of the library takes precedence
+		{"version", "1.1.1w", reqcheck.SchemeOpenSSL, reqcheck.SchemeOpenSSL},
+		{"version", "1.2.13.1", reqcheck.SchemeSemVer, reqcheck.SchemeSemVer},
+	}
+
+	for _, test := range tests {
+		_, scheme, err := parseVersionField("port", test.field, test.value, test.scheme)
+		if err != nil {
+			t.Errorf("%s %s: %v", test.field, test.value, err)
+			continue
+		}
+
+		if scheme.Name() != test.expected {
+			t.Errorf("%s %s: expected the %s scheme, got %s", test.field, test.value, test.expected, scheme.Name())
+		}
+	}
+
+	if _, _, err := parseVersionField("port", "version-date", "2024.1", ""); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+}
+
+func TestReadVcpkgVersionConstraint(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"ports/zlib/vcpkg.json":   `{"name": "zlib", "version": "1.2.3", "port-version": 2}`,
+		"ports/sqlite/vcpkg.json": `{"name": "sqlite", "version": "3.45.1.0"}`,
+	})
+
+	v := vcpkgRepository{Path: dir}
+
+	// Ranges and wildcards are accepted whatever the scheme of the port
+	for name, constraints := range map[string][]string{
+		"zlib":   {">= %s", "~%s", "^%s", "1.2.x"},
+		"sqlite": {">= %s", "~%s", "^%s", "3.45.x"},
+	} {
+		port, err := v.readVcpkgVersion(name, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range constraints {
+			constraint := c
+			if strings.Contains(c, "%s") {
+				constraint = fmt.Sprintf(c, port.Version)
+			}
+
+			parsed, err := reqcheck.NewConstraint(port.Scheme, constraint)
+			if err != nil {
+				t.Errorf("%s %s: %v", name, constraint, err)
+				continue
+			}
+			if !parsed.Check(port.Version) {
+				t.Errorf("%s %s: expected %s to satisfy the constraint", name, constraint, port.Version)
+			}
+		}
+	}
+}
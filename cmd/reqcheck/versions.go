@@ -0,0 +1,518 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type (
+	// baselineVersion is the version of a port within the baseline of a
+	// registry.
+	baselineVersion struct {
+		Baseline    string `json:"baseline"`
+		PortVersion int    `json:"port-version"`
+	}
+
+	// fileChange is the contents of a file before and after an update. A file
+	// being created has no original contents.
+	fileChange struct {
+		Path     string
+		Original []byte
+		Updated  []byte
+	}
+
+	// gitTreeEntry is an entry within a git tree object.
+	gitTreeEntry struct {
+		Mode string
+		Name string
+		Hash []byte
+	}
+)
+
+// String is the baseline including any port version.
+func (b baselineVersion) String() string {
+	if b.PortVersion > 0 {
+		return fmt.Sprintf("%s#%d", b.Baseline, b.PortVersion)
+	}
+
+	return b.Baseline
+}
+
+// registryPath is the root of the registry containing the port.
+func registryPath(portPath string) string {
+	return filepath.Dir(filepath.Dir(portPath))
+}
+
+// versionsPath is the version database of the registry.
+func versionsPath(portPath string) string {
+	return filepath.Join(registryPath(portPath), "versions")
+}
+
+// portVersionsPath is the file within the version database listing the
+// versions of the port.
+func portVersionsPath(portPath, name string) string {
+	return filepath.Join(versionsPath(portPath), name[:1]+"-", name+".json")
+}
+
+// readBaseline reads the version of the port within the baseline of the
+// registry containing it. A registry without a version database has no
+// baseline.
+func readBaseline(portPath, name string) (baselineVersion, bool, error) {
+	b, err := os.ReadFile(filepath.Join(versionsPath(portPath), "baseline.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return baselineVersion{}, false, nil
+	}
+	if err != nil {
+		return baselineVersion{}, false, err
+	}
+
+	var baseline struct {
+		Default map[string]baselineVersion `json:"default"`
+	}
+	if err = json.Unmarshal(b, &baseline); err != nil {
+		return baselineVersion{}, false, fmt.Errorf("could not parse baseline: %w", err)
+	}
+
+	version, ok := baseline.Default[name]
+
+	return version, ok, nil
+}
+
+// updateVersionDatabase adds the version of the updated port to the version
+// database of its registry and makes it the baseline. The git tree of the
+// port is computed with the changes to the port applied. Registries without a
+// version database are left untouched.
+func updateVersionDatabase(portPath, name, field, version string, changes []fileChange) ([]fileChange, error) {
+	if _, err := os.Stat(versionsPath(portPath)); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	updated := make(map[string][]byte, len(changes))
+	for _, change := range changes {
+		updated[change.Path] = change.Updated
+	}
+
+	gitTree, err := gitTreeHash(portPath, updated)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute git tree of %s: %w", name, err)
+	}
+
+	versions, err := readOptionalFile(portVersionsPath(portPath, name))
+	if err != nil {
+		return nil, err
+	}
+
+	updatedVersions, err := addVersionEntry(versions, field, version, gitTree)
+	if err != nil {
+		return nil, fmt.Errorf("could not add version of %s: %w", name, err)
+	}
+
+	baseline, err := readOptionalFile(filepath.Join(versionsPath(portPath), "baseline.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	updatedBaseline, err := setBaseline(baseline, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("could not update baseline of %s: %w", name, err)
+	}
+
+	return []fileChange{
+		{Path: portVersionsPath(portPath, name), Original: versions, Updated: updatedVersions},
+		{Path: filepath.Join(versionsPath(portPath), "baseline.json"), Original: baseline, Updated: updatedBaseline},
+	}, nil
+}
+
+// addVersionEntry adds a version to the start of the versions of a port.
+// Existing entries are left as written.
+func addVersionEntry(data []byte, field, version, gitTree string) ([]byte, error) {
+	entry := func(indent string) string {
+		inner := indent + indentUnit(indent)
+
+		return fmt.Sprintf("{\n%s\"git-tree\": %s,\n%s%s: %s,\n%s\"port-version\": 0\n%s}",
+			inner, jsonString(gitTree), inner, jsonString(field), jsonString(version), inner, indent)
+	}
+
+	if data == nil {
+		return []byte(fmt.Sprintf("{\n  \"versions\": [\n    %s\n  ]\n}\n", entry("    "))), nil
+	}
+
+	members, err := readJSONMembers(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if member.Key != "versions" {
+			continue
+		}
+		if data[member.ValueStart] != '[' {
+			return nil, fmt.Errorf("versions is not a list: %w", ErrCli)
+		}
+
+		// Insert before the first entry using the same separator as the list
+		list := data[member.ValueStart+1 : member.End]
+		first := member.End - len(bytes.TrimLeft(list, " \t\r\n"))
+
+		if data[first] == ']' {
+			indent := lineIndent(data, member.Start)
+			inner := indent + indentUnit(indent)
+			text := fmt.Sprintf("[\n%s%s\n%s]", inner, entry(inner), indent)
+
+			return applyEdits(data, []textEdit{{Start: member.ValueStart, End: member.End, Text: text}}), nil
+		}
+
+		text := entry(lineIndent(data, first)) + "," + string(data[member.ValueStart+1:first])
+
+		return applyEdits(data, []textEdit{{Start: first, End: first, Text: text}}), nil
+	}
+
+	return nil, fmt.Errorf("could not find versions: %w", ErrCli)
+}
+
+// setBaseline sets the baseline of a port. Ports are added in order by name
+// when not already present.
+func setBaseline(data []byte, name, version string) ([]byte, error) {
+	entry := func(indent string) string {
+		inner := indent + indentUnit(indent)
+
+		return fmt.Sprintf("{\n%s\"baseline\": %s,\n%s\"port-version\": 0\n%s}", inner, jsonString(version), inner, indent)
+	}
+
+	if data == nil {
+		return []byte(fmt.Sprintf("{\n  \"default\": {\n    %s: %s\n  }\n}\n", jsonString(name), entry("    "))), nil
+	}
+
+	members, err := readJSONMembers(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if member.Key != "default" {
+			continue
+		}
+
+		ports, err := readJSONMembers(data[member.ValueStart:member.End])
+		if err != nil {
+			return nil, err
+		}
+		for i := range ports {
+			ports[i].Start += member.ValueStart
+			ports[i].ValueStart += member.ValueStart
+			ports[i].End += member.ValueStart
+		}
+
+		if len(ports) == 0 {
+			indent := lineIndent(data, member.Start)
+			inner := indent + indentUnit(indent)
+			text := fmt.Sprintf("{\n%s%s: %s\n%s}", inner, jsonString(name), entry(inner), indent)
+
+			return applyEdits(data, []textEdit{{Start: member.ValueStart, End: member.End, Text: text}}), nil
+		}
+
+		// Replace the existing entry
+		for _, port := range ports {
+			if port.Key == name {
+				text := entry(lineIndent(data, port.Start))
+
+				return applyEdits(data, []textEdit{{Start: port.ValueStart, End: port.End, Text: text}}), nil
+			}
+		}
+
+		i := 0
+		for i < len(ports) && ports[i].Key < name {
+			i++
+		}
+
+		// Separate the new entry in the same way as the others
+		var sep string
+		if len(ports) > 1 {
+			sep = string(data[ports[0].End:ports[1].Start])
+		} else {
+			sep = ",\n" + lineIndent(data, ports[0].Start)
+		}
+
+		if i < len(ports) {
+			text := jsonString(name) + ": " + entry(lineIndent(data, ports[i].Start)) + sep
+
+			return applyEdits(data, []textEdit{{Start: ports[i].Start, End: ports[i].Start, Text: text}}), nil
+		}
+
+		last := ports[len(ports)-1]
+		text := sep + jsonString(name) + ": " + entry(lineIndent(data, last.Start))
+
+		return applyEdits(data, []textEdit{{Start: last.End, End: last.End, Text: text}}), nil
+	}
+
+	return nil, fmt.Errorf("could not find default baseline: %w", ErrCli)
+}
+
+// gitTreeHash computes the hash git gives the tree of the directory once the
+// updated files are staged. Within a git repository the other files are read
+// from the index, so untracked and ignored files are excluded, and the line
+// endings of the updated files are converted as git would.
+func gitTreeHash(dir string, updated map[string][]byte) (string, error) {
+	repo, ok, err := findGitRepository(dir)
+	if err != nil {
+		return "", fmt.Errorf("could not find git repository: %w", err)
+	}
+
+	var hash []byte
+	if ok {
+		hash, err = hashGitIndex(repo, dir, updated)
+	} else {
+		logrus.WithField("path", dir).Warn("port is not within a git repository so every file is part of the git tree")
+
+		hash, err = hashGitTree(dir, updated)
+	}
+	if err != nil {
+		return "", err
+	}
+	if hash == nil {
+		hash = gitObjectHash("tree", nil)
+	}
+
+	return hex.EncodeToString(hash), nil
+}
+
+// hashGitIndex returns the hash of the tree of the directory from the entries
+// within the index, replacing those of the updated files.
+func hashGitIndex(repo gitRepository, dir string, updated map[string][]byte) ([]byte, error) {
+	index, err := repo.readIndex()
+	if err != nil {
+		return nil, fmt.Errorf("could not read git index: %w", err)
+	}
+
+	// Paths within the index are relative to the work tree
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	prefix, err := filepath.Rel(repo.WorkTree, abs)
+	if err != nil {
+		return nil, err
+	}
+	prefix = filepath.ToSlash(prefix) + "/"
+	if prefix == "./" {
+		prefix = ""
+	}
+
+	entries := make(map[string]gitTreeEntry)
+	for _, entry := range index {
+		name, ok := strings.CutPrefix(entry.Path, prefix)
+		if !ok {
+			continue
+		}
+		if entry.Stage != 0 {
+			return nil, fmt.Errorf("could not hash %s with unresolved conflicts: %w", name, ErrCli)
+		}
+
+		// A sparse index holds the tree of a directory outside of the checkout
+		mode := fmt.Sprintf("%o", entry.Mode)
+		if entry.Mode&0o170000 == gitModeDirectory {
+			mode, name = "40000", strings.TrimSuffix(name, "/")
+		}
+
+		entries[name] = gitTreeEntry{Mode: mode, Name: name, Hash: entry.Hash}
+	}
+
+	for file, content := range updated {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+
+		entry, ok := entries[rel]
+		if !ok {
+			entry = gitTreeEntry{Mode: "100644", Name: rel}
+		}
+		entry.Hash = gitObjectHash("blob", repo.convertToGit(prefix+rel, content))
+		entries[rel] = entry
+	}
+
+	tree := make([]gitTreeEntry, 0, len(entries))
+	for _, entry := range entries {
+		tree = append(tree, entry)
+	}
+
+	return hashGitEntries(tree), nil
+}
+
+// hashGitEntries returns the hash of the tree holding the entries, whose names
+// are paths within the tree.
+func hashGitEntries(entries []gitTreeEntry) []byte {
+	var tree []gitTreeEntry
+	dirs := make(map[string][]gitTreeEntry)
+
+	for _, entry := range entries {
+		dir, rest, nested := strings.Cut(entry.Name, "/")
+		if !nested {
+			tree = append(tree, entry)
+			continue
+		}
+
+		dirs[dir] = append(dirs[dir], gitTreeEntry{Mode: entry.Mode, Name: rest, Hash: entry.Hash})
+	}
+
+	for name, children := range dirs {
+		tree = append(tree, gitTreeEntry{Mode: "40000", Name: name, Hash: hashGitEntries(children)})
+	}
+
+	return writeGitTree(tree)
+}
+
+// hashGitTree returns the hash of the tree object of the directory from the
+// files on disk, with CRLF line endings of text files converted as git does
+// when checking them in. Git does not track empty directories so they have no
+// hash.
+func hashGitTree(dir string, updated map[string][]byte) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree []gitTreeEntry
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		info, err := os.Lstat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case info.IsDir():
+			hash, err := hashGitTree(path, updated)
+			if err != nil {
+				return nil, err
+			}
+			if hash != nil {
+				tree = append(tree, gitTreeEntry{Mode: "40000", Name: entry.Name(), Hash: hash})
+			}
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return nil, err
+			}
+
+			tree = append(tree, gitTreeEntry{Mode: "120000", Name: entry.Name(), Hash: gitObjectHash("blob", []byte(filepath.ToSlash(target)))})
+		case info.Mode().IsRegular():
+			content, ok := updated[path]
+			if !ok {
+				if content, err = os.ReadFile(path); err != nil {
+					return nil, err
+				}
+			}
+
+			// Binary files are left as is
+			if !bytes.Contains(content, []byte{0}) {
+				content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+			}
+
+			mode := "100644"
+			if info.Mode()&0o111 != 0 {
+				mode = "100755"
+			}
+
+			tree = append(tree, gitTreeEntry{Mode: mode, Name: entry.Name(), Hash: gitObjectHash("blob", content)})
+		}
+	}
+
+	return writeGitTree(tree), nil
+}
+
+// writeGitTree returns the hash of the tree object holding the entries. An
+// empty tree has no hash.
+func writeGitTree(tree []gitTreeEntry) []byte {
+	if len(tree) == 0 {
+		return nil
+	}
+
+	// Git orders directories as if their name ends with a slash
+	sortName := func(e gitTreeEntry) string {
+		if e.Mode == "40000" {
+			return e.Name + "/"
+		}
+
+		return e.Name
+	}
+	sort.Slice(tree, func(i, j int) bool {
+		return sortName(tree[i]) < sortName(tree[j])
+	})
+
+	var buf bytes.Buffer
+	for _, e := range tree {
+		fmt.Fprintf(&buf, "%s %s\x00", e.Mode, e.Name)
+		buf.Write(e.Hash)
+	}
+
+	return gitObjectHash("tree", buf.Bytes())
+}
+
+func gitObjectHash(kind string, content []byte) []byte {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s %d\x00", kind, len(content))
+	h.Write(content)
+
+	return h.Sum(nil)
+}
+
+// readOptionalFile reads a file which may not exist yet.
+func readOptionalFile(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	return b, nil
+}
+
+// lineIndent returns the whitespace starting the line containing the offset.
+func lineIndent(data []byte, offset int) string {
+	start := bytes.LastIndexByte(data[:offset], '\n') + 1
+
+	end := start
+	for end < offset && (data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+
+	return string(data[start:end])
+}
+
+// indentUnit is the additional indentation of a nested value.
+func indentUnit(indent string) string {
+	if strings.HasPrefix(indent, "\t") {
+		return "\t"
+	}
+
+	return "  "
+}
+
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+
+	return string(b)
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates the files within the directory.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// git runs git within the directory failing the test on an error.
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %s: %v", strings.Join(args, " "), out, err)
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// testPortFiles are the files of a port checked out with CRLF line endings.
+var testPortFiles = map[string]string{
+	"ports/zlib/vcpkg.json":             "{\r\n  \"name\": \"zlib\",\r\n  \"version\": \"1.3\"\r\n}\r\n",
+	"ports/zlib/portfile.cmake":         "vcpkg_from_github(\r\n    REF v1.3\r\n)\r\n",
+	"ports/zlib/patches/fix-build.diff": "--- a\r\n+++ b\r\n",
+}
+
+// newGitRepository creates a repository with the files staged, failing the
+// test when git is not available.
+func newGitRepository(t *testing.T, config ...string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	dir := t.TempDir()
+	git(t, dir, "init", "-q")
+	for i := 0; i+1 < len(config); i += 2 {
+		git(t, dir, "config", config[i], config[i+1])
+	}
+
+	writeFiles(t, dir, testPortFiles)
+	writeFiles(t, dir, map[string]string{".gitignore": "*.log\n"})
+	git(t, dir, "add", ".")
+
+	return dir
+}
+
+// expectedGitTree stages the update and returns the tree git gives the port.
+func expectedGitTree(t *testing.T, dir, file string, content []byte) string {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(file)), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(t, dir, "add", file)
+
+	return git(t, dir, "rev-parse", git(t, dir, "write-tree")+":ports/zlib")
+}
+
+func TestGitTreeHash(t *testing.T) {
+	dir := newGitRepository(t, "core.autocrlf", "true")
+
+	// Neither untracked nor ignored files are part of the tree
+	writeFiles(t, dir, map[string]string{
+		"ports/zlib/notes.txt": "untracked",
+		"ports/zlib/build.log": "ignored",
+	})
+
+	portPath := filepath.Join(dir, "ports", "zlib")
+	portfile := filepath.Join(portPath, "portfile.cmake")
+	updated := []byte("vcpkg_from_github(\r\n    REF v1.3.1\r\n)\r\n")
+
+	hash, err := gitTreeHash(portPath, map[string][]byte{portfile: updated})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := expectedGitTree(t, dir, "ports/zlib/portfile.cmake", updated)
+	if hash != expected {
+		t.Errorf("expected git tree %s, got %s", expected, hash)
+	}
+
+	// Without a repository the line endings are still converted
+	plain := t.TempDir()
+	writeFiles(t, plain, testPortFiles)
+
+	hash, err = gitTreeHash(filepath.Join(plain, "ports", "zlib"), map[string][]byte{filepath.Join(plain, "ports", "zlib", "portfile.cmake"): updated})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != expected {
+		t.Errorf("expected git tree %s outside of a repository, got %s", expected, hash)
+	}
+}
+
+func TestGitTreeHashIndexVersions(t *testing.T) {
+	for _, version := range []string{"2", "3", "4"} {
+		dir := newGitRepository(t)
+
+		// Executable and intent to add files are within the index
+		writeFiles(t, dir, map[string]string{
+			"ports/zlib/build.sh":                          "#!/bin/sh\n",
+			"ports/zlib/patches/a-long-name-to-strip.diff": "+++ b\n",
+			"ports/zlib/later.txt":                         "not staged",
+		})
+		if err := os.Chmod(filepath.Join(dir, "ports", "zlib", "build.sh"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		git(t, dir, "add", "ports/zlib/build.sh", "ports/zlib/patches")
+		git(t, dir, "add", "--intent-to-add", "ports/zlib/later.txt")
+		git(t, dir, "update-index", "--index-version", version)
+
+		portPath := filepath.Join(dir, "ports", "zlib")
+		updated := []byte("{\n  \"name\": \"zlib\",\n  \"version\": \"1.3.1\"\n}\n")
+
+		hash, err := gitTreeHash(portPath, map[string][]byte{filepath.Join(portPath, "vcpkg.json"): updated})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		git(t, dir, "rm", "-q", "--cached", "ports/zlib/later.txt")
+		expected := expectedGitTree(t, dir, "ports/zlib/vcpkg.json", updated)
+		if hash != expected {
+			t.Errorf("index version %s: expected git tree %s, got %s", version, expected, hash)
+		}
+	}
+}
+
+func TestGitTreeHashAttributes(t *testing.T) {
+	dir := newGitRepository(t)
+	writeFiles(t, dir, map[string]string{
+		".gitattributes":            "* text=auto\n*.cmake -text\n",
+		"ports/zlib/.gitattributes": "/vcpkg.json text\n",
+	})
+	git(t, dir, "add", ".")
+
+	portPath := filepath.Join(dir, "ports", "zlib")
+	updated := map[string][]byte{
+		filepath.Join(portPath, "portfile.cmake"):            []byte("vcpkg_from_github(\r\n    REF v1.3.1\r\n)\r\n"),
+		filepath.Join(portPath, "vcpkg.json"):                []byte("{\r\n  \"name\": \"zlib\"\r\n}\r\n"),
+		filepath.Join(portPath, "patches", "fix-build.diff"): []byte("--- a\r\n+++ b\r\n\x00"),
+	}
+
+	hash, err := gitTreeHash(portPath, updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for file, content := range updated {
+		if err = os.WriteFile(file, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git(t, dir, "add", ".")
+
+	expected := git(t, dir, "rev-parse", git(t, dir, "write-tree")+":ports/zlib")
+	if hash != expected {
+		t.Errorf("expected git tree %s, got %s", expected, hash)
+	}
+}
+
+func TestGitTreeHashWorktree(t *testing.T) {
+	dir := newGitRepository(t, "core.autocrlf", "true")
+	git(t, dir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "zlib")
+
+	worktree := filepath.Join(t.TempDir(), "worktree")
+	git(t, dir, "worktree", "add", "-q", worktree)
+
+	portPath := filepath.Join(worktree, "ports", "zlib")
+	updated := []byte("vcpkg_from_github(\r\n    REF v1.3.1\r\n)\r\n")
+
+	// The config of the main repository applies to the linked work tree
+	hash, err := gitTreeHash(portPath, map[string][]byte{filepath.Join(portPath, "portfile.cmake"): updated})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := expectedGitTree(t, worktree, "ports/zlib/portfile.cmake", updated)
+	if hash != expected {
+		t.Errorf("expected git tree %s, got %s", expected, hash)
+	}
+}
+
+func TestAddVersionEntry(t *testing.T) {
+	versions := "{\n  \"versions\": [\n    {\n      \"git-tree\": \"abc\",\n      \"version\": \"1.2\",\n      \"port-version\": 0\n    }\n  ]\n}\n"
+
+	updated, err := addVersionEntry([]byte(versions), "version", "1.3", "def")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "{\n  \"versions\": [\n    {\n      \"git-tree\": \"def\",\n      \"version\": \"1.3\",\n      \"port-version\": 0\n    },\n    {\n      \"git-tree\": \"abc\",\n      \"version\": \"1.2\",\n      \"port-version\": 0\n    }\n  ]\n}\n"
+	if string(updated) != expected {
+		t.Errorf("unexpected versions\n%s", updated)
+	}
+}
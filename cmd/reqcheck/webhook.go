@@ -0,0 +1,103 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+// webhookDefaultTmpl sends the same entries as the json format.
+const webhookDefaultTmpl = `{{ json .Results }}`
+
+type (
+	// webhookNotifier sends a body created from a template to a url.
+	webhookNotifier struct {
+		URL      string
+		Method   string
+		Header   http.Header
+		Template *template.Template
+	}
+
+	// webhookData is available to the template of a webhook.
+	webhookData struct {
+		report
+		// Results holds an entry for each library as in the json format.
+		Results []libraryResult
+		// Summary counts the libraries in each state.
+		Summary string
+	}
+)
+
+func newWebhookNotifier(un map[string]interface{}) (notifier, error) {
+	u, err := requireString(un, "url")
+	if err != nil {
+		return nil, err
+	}
+
+	n := webhookNotifier{URL: u, Method: http.MethodPost, Header: http.Header{}}
+
+	if method, ok := un["method"].(string); ok {
+		n.Method = strings.ToUpper(method)
+	}
+
+	if headers, ok := un["headers"].(map[string]interface{}); ok {
+		for key, val := range headers {
+			value, err := parseSecret(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid header %s: %w", key, err)
+			}
+
+			n.Header.Set(key, value)
+		}
+	}
+
+	tmpl := webhookDefaultTmpl
+	if text, ok := un["template"].(string); ok && text != "" {
+		tmpl = strings.TrimSpace(text)
+	}
+
+	n.Template, err = template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := marshalMessage(v)
+			return string(b), err
+		},
+	}).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse template: %w", err)
+	}
+
+	return n, nil
+}
+
+func (n webhookNotifier) messages(r report) ([][]byte, error) {
+	data := webhookData{report: r, Summary: r.summary()}
+	for _, release := range r.Current {
+		data.Results = append(data.Results, newLibraryResult(statusCurrent, release))
+	}
+	for _, release := range r.Upgrade {
+		data.Results = append(data.Results, newLibraryResult(statusUpgrade, release))
+	}
+	for _, failure := range r.Errors {
+		data.Results = append(data.Results, newLibraryErrorResult(failure))
+	}
+
+	var buf bytes.Buffer
+	if err := n.Template.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("could not execute template: %w", err)
+	}
+
+	return [][]byte{buf.Bytes()}, nil
+}
+
+func (n webhookNotifier) send(ctx context.Context, cl *http.Client, body []byte) error {
+	_, err := postBody(ctx, cl, n.Method, n.URL, n.Header, body)
+
+	return err
+}
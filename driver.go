@@ -0,0 +1,105 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+type (
+	// DriverConfig is the configuration of a scm passed to a DriverFactory.
+	DriverConfig struct {
+		// URI of the scm instance.
+		URI string
+		// Token used to authenticate with the scm.
+		Token string
+		// HTTPClient to send requests with. Retries and caching are already
+		// applied by its transport.
+		HTTPClient *http.Client
+		// Options holds any driver specific settings.
+		Options map[string]interface{}
+	}
+
+	// DriverFactory creates a client for a scm.
+	DriverFactory func(config DriverConfig) (Client, error)
+)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]DriverFactory)
+)
+
+// RegisterDriver makes a driver available by name to NewClientFromDriver and
+// NewClientFromDriverWithOptions. It panics if the factory is nil or a driver
+// is already registered with the name.
+func RegisterDriver(name string, factory DriverFactory) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	if factory == nil {
+		panic("reqcheck: driver factory is nil")
+	}
+	if _, dup := drivers[name]; dup {
+		panic("reqcheck: driver registered twice " + name)
+	}
+
+	drivers[name] = factory
+}
+
+// Drivers returns the names of the registered drivers in sorted order.
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+func driverFactory(name string) (DriverFactory, bool) {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	factory, ok := drivers[name]
+
+	return factory, ok
+}
+
+// String returns the option as a string, which is empty when it is not set.
+func (c DriverConfig) String(key string) (string, error) {
+	val, ok := c.Options[key]
+	if !ok || val == nil {
+		return "", nil
+	}
+
+	s, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("option %s must be a string: %w", key, ErrScmDriver)
+	}
+
+	return s, nil
+}
+
+// Bool returns the option as a bool, which is false when it is not set.
+func (c DriverConfig) Bool(key string) (bool, error) {
+	val, ok := c.Options[key]
+	if !ok || val == nil {
+		return false, nil
+	}
+
+	b, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("option %s must be a bool: %w", key, ErrScmDriver)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// registerTestDriver registers the factory for the duration of the test.
+func registerTestDriver(t *testing.T, name string, factory DriverFactory) {
+	t.Helper()
+
+	RegisterDriver(name, factory)
+	t.Cleanup(func() {
+		driversMu.Lock()
+		defer driversMu.Unlock()
+
+		delete(drivers, name)
+	})
+}
+
+// expectPanic fails the test unless f panics with the message.
+func expectPanic(t *testing.T, message string, f func()) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r != message {
+			t.Errorf("expected a panic of %q, got %v", message, r)
+		}
+	}()
+
+	f()
+}
+
+func TestRegisterDriver(t *testing.T) {
+	var config DriverConfig
+	registerTestDriver(t, "test", func(c DriverConfig) (Client, error) {
+		config = c
+
+		return &fakeClient{}, nil
+	})
+
+	client, err := NewClientFromDriverWithOptions("test", "https://example.com", "secret", ClientOptions{
+		Pattern: `^v(?P<major>\d+)$`,
+		GraphQL: true,
+		Options: map[string]interface{}{"project": "PROJ"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := client.(*fakeClient); !ok {
+		t.Errorf("unexpected client %T", client)
+	}
+
+	// Settings with a field of their own are passed as options
+	if config.URI != "https://example.com" || config.Token != "secret" || config.HTTPClient == nil || len(config.Options) != 3 {
+		t.Errorf("unexpected config %+v", config)
+	}
+
+	expectPanic(t, "reqcheck: driver registered twice test", func() {
+		RegisterDriver("test", func(DriverConfig) (Client, error) { return nil, nil })
+	})
+	expectPanic(t, "reqcheck: driver factory is nil", func() {
+		RegisterDriver("nil", nil)
+	})
+
+	if _, err = NewClientFromDriver("nil", "https://example.com", ""); !errors.Is(err, ErrScmDriver) {
+		t.Errorf("expected an unknown driver, got %v", err)
+	}
+}
+
+func TestDrivers(t *testing.T) {
+	registerTestDriver(t, "aaa", func(DriverConfig) (Client, error) { return nil, nil })
+
+	names := Drivers()
+
+	expected := []string{"aaa", DriverBitbucket, DriverGit, DriverGitea, DriverGitHub, DriverGitLab, DriverHTTPIndex}
+	for _, name := range expected {
+		if !slices.Contains(names, name) {
+			t.Errorf("expected the driver %s within %v", name, names)
+		}
+	}
+	if !slices.IsSorted(names) || names[0] != "aaa" {
+		t.Errorf("expected sorted drivers, got %v", names)
+	}
+}
+
+func TestDriverConfigOptions(t *testing.T) {
+	config := DriverConfig{Options: map[string]interface{}{
+		"project": "PROJ",
+		"graphql": true,
+		"empty":   nil,
+		"number":  1,
+	}}
+
+	tests := []struct {
+		key     string
+		str     string
+		strErr  bool
+		boolean bool
+		boolErr bool
+	}{
+		{"project", "PROJ", false, false, true},
+		{"graphql", "", true, true, false},
+		{"empty", "", false, false, false},
+		{"missing", "", false, false, false},
+		{"number", "", true, false, true},
+	}
+
+	for _, test := range tests {
+		s, err := config.String(test.key)
+		if s != test.str || (err != nil) != test.strErr {
+			t.Errorf("%s: unexpected string %q %v", test.key, s, err)
+		}
+		if err != nil && !errors.Is(err, ErrScmDriver) {
+			t.Errorf("%s: unexpected error %v", test.key, err)
+		}
+
+		b, err := config.Bool(test.key)
+		if b != test.boolean || (err != nil) != test.boolErr {
+			t.Errorf("%s: unexpected bool %v %v", test.key, b, err)
+		}
+	}
+
+	// Options are optional
+	if s, err := (DriverConfig{}).String("project"); s != "" || err != nil {
+		t.Errorf("unexpected string %q %v", s, err)
+	}
+}
@@ -4,26 +4,98 @@
 
 package reqcheck
 
-import "github.com/Masterminds/semver"
+import (
+	"iter"
 
-func FilterSemanticVersion(item interface{}) bool {
-	release := item.(Release)
+	"github.com/Masterminds/semver"
+)
+
+// Predicate reports whether a release should be kept.
+type Predicate func(Release) bool
+
+// Filter iterates over the releases that satisfy the predicate. Errors are
+// passed through.
+func Filter(seq iter.Seq2[Release, error], p Predicate) iter.Seq2[Release, error] {
+	return func(yield func(Release, error) bool) {
+		for release, err := range seq {
+			if err == nil && !p(release) {
+				continue
+			}
+
+			if !yield(release, err) {
+				return
+			}
+		}
+	}
+}
 
+// And is satisfied when all the predicates are.
+func And(predicates ...Predicate) Predicate {
+	return func(release Release) bool {
+		for _, p := range predicates {
+			if !p(release) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Or is satisfied when any of the predicates are.
+func Or(predicates ...Predicate) Predicate {
+	return func(release Release) bool {
+		for _, p := range predicates {
+			if p(release) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not is satisfied when the predicate is not.
+func Not(p Predicate) Predicate {
+	return func(release Release) bool {
+		return !p(release)
+	}
+}
+
+// HasSemanticVersion keeps releases with a semantic version.
+func HasSemanticVersion(release Release) bool {
 	return release.SemVer != nil
 }
 
-func FilterStableRelease(item interface{}) bool {
-	release := item.(Release)
-	if release.SemVer == nil {
+// HasVersion keeps releases with a version in the scheme of the library.
+func HasVersion(release Release) bool {
+	return release.Version != nil
+}
+
+// IsStableRelease keeps releases whose version is not a pre-release.
+func IsStableRelease(release Release) bool {
+	if release.Version == nil {
 		return false
 	}
 
-	return release.SemVer.Prerelease() == ""
+	return release.Version.Prerelease() == ""
 }
 
-func FilterSemanticConstraint(c *semver.Constraints) func(interface{}) bool {
-	return func(item interface{}) bool {
-		release := item.(Release)
+// IsPublishedRelease keeps releases not marked as a draft upstream.
+func IsPublishedRelease(release Release) bool {
+	return !release.Draft
+}
+
+// IsUpstreamStableRelease keeps releases not marked as a pre-release
+// upstream, regardless of their version.
+func IsUpstreamStableRelease(release Release) bool {
+	return !release.Prerelease
+}
+
+// SatisfiesSemanticConstraint keeps releases whose semantic version satisfies
+// the constraint.
+func SatisfiesSemanticConstraint(c *semver.Constraints) Predicate {
+	return func(release Release) bool {
 		if release.SemVer == nil {
 			return false
 		}
@@ -31,3 +103,59 @@ func FilterSemanticConstraint(c *semver.Constraints) func(interface{}) bool {
 		return c.Check(release.SemVer)
 	}
 }
+
+// SatisfiesConstraint keeps releases whose version satisfies the constraint.
+func SatisfiesConstraint(c *Constraint) Predicate {
+	return func(release Release) bool {
+		if release.Version == nil {
+			return false
+		}
+
+		return c.Check(release.Version)
+	}
+}
+
+// RxFilter adapts the predicate for use with an rxgo.Observable. Items that
+// are not a Release are removed.
+func RxFilter(p Predicate) func(interface{}) bool {
+	return func(item interface{}) bool {
+		release, ok := item.(Release)
+
+		return ok && p(release)
+	}
+}
+
+func FilterSemanticVersion(item interface{}) bool {
+	return RxFilter(HasSemanticVersion)(item)
+}
+
+// FilterVersion removes releases without a version in the scheme of the
+// library.
+func FilterVersion(item interface{}) bool {
+	return RxFilter(HasVersion)(item)
+}
+
+func FilterStableRelease(item interface{}) bool {
+	return RxFilter(IsStableRelease)(item)
+}
+
+// FilterPublishedRelease removes releases marked as a draft upstream.
+func FilterPublishedRelease(item interface{}) bool {
+	return RxFilter(IsPublishedRelease)(item)
+}
+
+// FilterUpstreamStableRelease removes releases marked as a pre-release
+// upstream, regardless of their version.
+func FilterUpstreamStableRelease(item interface{}) bool {
+	return RxFilter(IsUpstreamStableRelease)(item)
+}
+
+func FilterSemanticConstraint(c *semver.Constraints) func(interface{}) bool {
+	return RxFilter(SatisfiesSemanticConstraint(c))
+}
+
+// FilterConstraint removes releases whose version does not satisfy the
+// constraint.
+func FilterConstraint(c *Constraint) func(interface{}) bool {
+	return RxFilter(SatisfiesConstraint(c))
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"testing"
+)
+
+// testReleases yields the releases followed by any error.
+func testReleases(releases []Release, err error) iter.Seq2[Release, error] {
+	return func(yield func(Release, error) bool) {
+		for _, release := range releases {
+			if !yield(release, nil) {
+				return
+			}
+		}
+
+		if err != nil {
+			yield(Release{}, err)
+		}
+	}
+}
+
+// dottedRelease creates a release of the tag with a dotted version.
+func dottedRelease(t *testing.T, tag string) Release {
+	t.Helper()
+
+	release := Release{Tag: tag, SemVer: generateVersion(tag, versionMatcher)}
+	if version, err := schemes[SchemeDotted].Parse(tag); err == nil {
+		release.Version = version
+	}
+
+	return release
+}
+
+func TestPredicates(t *testing.T) {
+	releases := []Release{
+		dottedRelease(t, "v1.0"),
+		dottedRelease(t, "v2.0"),
+		dottedRelease(t, "v3.0-rc1"),
+		{Tag: "v4.0", Version: SemVerVersion(generateVersion("v4.0.0-beta.1", versionMatcher)), Draft: true},
+	}
+
+	constraint, err := NewConstraint(schemes[SchemeDotted], ">= 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		predicate Predicate
+		expected  string
+	}{
+		{"has version", HasVersion, "[v1.0 v2.0 v4.0]"},
+		{"stable", IsStableRelease, "[v1.0 v2.0]"},
+		{"published", IsPublishedRelease, "[v1.0 v2.0 v3.0-rc1]"},
+		{"constraint", SatisfiesConstraint(constraint), "[v2.0]"},
+		{"and", And(HasVersion, IsPublishedRelease), "[v1.0 v2.0]"},
+		{"and none", And(), "[v1.0 v2.0 v3.0-rc1 v4.0]"},
+		{"or", Or(Not(HasVersion), SatisfiesConstraint(constraint)), "[v2.0 v3.0-rc1]"},
+		{"or none", Or(), "[]"},
+		{"not", Not(IsStableRelease), "[v3.0-rc1 v4.0]"},
+	}
+
+	for _, test := range tests {
+		var tags []string
+		for release, err := range Filter(testReleases(releases, nil), test.predicate) {
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tags = append(tags, release.Tag)
+		}
+
+		if fmt.Sprint(tags) != test.expected {
+			t.Errorf("%s: expected %s, got %v", test.name, test.expected, tags)
+		}
+	}
+}
+
+func TestFilterPassesErrors(t *testing.T) {
+	failed := errors.New("failed")
+
+	var errs []error
+	for _, err := range Filter(testReleases([]Release{dottedRelease(t, "v1.0")}, failed), Not(HasVersion)) {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], failed) {
+		t.Errorf("expected only the error, got %v", errs)
+	}
+}
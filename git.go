@@ -0,0 +1,314 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	gitUploadPack  = "git-upload-pack"
+	gitTagPrefix   = "refs/tags/"
+	gitPeeledRef   = "^{}"
+	gitUserAgent   = "git/reqcheck"
+	gitProtocolV2  = "version=2"
+	gitFlushPkt    = "0000"
+	gitDelimPkt    = "0001"
+	gitPktLenBytes = 4
+)
+
+type (
+	gitClient struct {
+		client  *http.Client
+		baseURL *url.URL
+		token   string
+
+		mu   sync.Mutex
+		tags map[string][]Release
+	}
+
+	gitRef struct {
+		Name   string
+		Commit string
+	}
+)
+
+func init() {
+	RegisterDriver(DriverGit, func(config DriverConfig) (Client, error) {
+		return NewGitClient(config.URI, config.Token, config.HTTPClient)
+	})
+}
+
+// NewGit creates a client for plain git repositories hosted over HTTP.
+//
+// Tags are queried through the smart HTTP protocol so no git binary is
+// required. The repository is located at <uri>/<owner>/<name> where an empty
+// owner is omitted.
+func NewGit(uri, token string) (Client, error) {
+	return NewGitClient(uri, token, http.DefaultClient)
+}
+
+func NewGitClient(uri, token string, cl *http.Client) (Client, error) {
+	// Parse the url
+	gitURL, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse git link: %w", err)
+	}
+
+	logrus.WithField("git-url", gitURL.String()).Debug("connecting to git host")
+
+	return &gitClient{client: cl, baseURL: gitURL, token: token, tags: make(map[string][]Release)}, nil
+}
+
+// ListReleases falls back to tags as plain git repositories have no concept
+// of a release.
+func (c *gitClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	return c.ListTags(ctx, owner, name, opt)
+}
+
+// ListTags lists the tags of the repository.
+//
+// The remote advertises every tag in a single response so it is only queried
+// when the first page is requested. Tags are ordered from the greatest version
+// to the lowest, with tags that are not versions last, to match the ordering
+// of the forge drivers.
+func (c *gitClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	repoURL := c.repositoryURL(owner, name)
+
+	logrus.WithFields(logrus.Fields{
+		"repository": repoURL,
+		"page":       opt.Page,
+		"per-page":   opt.PerPage,
+	}).Debug("listing git tags")
+
+	c.mu.Lock()
+	tags, ok := c.tags[repoURL]
+	c.mu.Unlock()
+
+	if !ok || opt.Page <= startingPage {
+		refs, err := c.listRefs(ctx, repoURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting tags from repository %s: %w", repoURL, err)
+		}
+
+		tags = make([]Release, 0, len(refs))
+
+		for _, ref := range refs {
+			tagName := ref.Name
+
+			logrus.WithFields(logrus.Fields{
+				"tag":    tagName,
+				"commit": ref.Commit,
+			}).Debug("found tag")
+
+			tags = append(tags, Release{
+				Tag:    tagName,
+				SemVer: generateVersion(tagName, versionMatcher),
+				Commit: ref.Commit,
+			})
+		}
+
+		sortReleases(tags)
+
+		c.mu.Lock()
+		c.tags[repoURL] = tags
+		c.mu.Unlock()
+	}
+
+	page, resp := paginate(tags, opt)
+
+	return page, resp, nil
+}
+
+func (c *gitClient) repositoryURL(owner, name string) string {
+	u := *c.baseURL
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	for _, segment := range []string{owner, name} {
+		if segment != "" {
+			u.Path += "/" + strings.Trim(segment, "/")
+		}
+	}
+
+	return u.String()
+}
+
+// listRefs lists the tags of a repository along with the commit they point to.
+//
+// Protocol v2 is requested from the remote. Remotes that only support the
+// original protocol respond with their refs directly.
+func (c *gitClient) listRefs(ctx context.Context, repoURL string) ([]gitRef, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, repoURL+"/info/refs?service="+gitUploadPack, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+
+	body, err := doRequest(c.client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := readPktLines(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip the service announcement
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "# service=") {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+
+	if len(lines) > 0 && lines[0] == "version 2" {
+		return c.lsRefs(ctx, repoURL)
+	}
+
+	return parseAdvertisedRefs(lines), nil
+}
+
+// lsRefs issues the protocol v2 ls-refs command for tags.
+func (c *gitClient) lsRefs(ctx context.Context, repoURL string) ([]gitRef, error) {
+	var request bytes.Buffer
+	writePktLine(&request, "command=ls-refs\n")
+	writePktLine(&request, "agent="+gitUserAgent+"\n")
+	request.WriteString(gitDelimPkt)
+	writePktLine(&request, "peel\n")
+	writePktLine(&request, "ref-prefix "+gitTagPrefix+"\n")
+	request.WriteString(gitFlushPkt)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, repoURL+"/"+gitUploadPack, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/x-"+gitUploadPack+"-request")
+	req.Header.Set("Accept", "application/x-"+gitUploadPack+"-result")
+
+	body, err := doRequest(c.client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := readPktLines(body)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]gitRef, 0, len(lines))
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.HasPrefix(fields[1], gitTagPrefix) {
+			continue
+		}
+
+		ref := gitRef{Name: strings.TrimPrefix(fields[1], gitTagPrefix), Commit: fields[0]}
+		for _, attr := range fields[2:] {
+			if peeled, ok := strings.CutPrefix(attr, "peeled:"); ok {
+				ref.Commit = peeled
+			}
+		}
+
+		refs = append(refs, ref)
+	}
+
+	return refs, nil
+}
+
+func (c *gitClient) setHeaders(req *http.Request) {
+	req.Header.Set("Git-Protocol", gitProtocolV2)
+	req.Header.Set("User-Agent", gitUserAgent)
+
+	if c.token != "" {
+		req.SetBasicAuth("git", c.token)
+	}
+}
+
+// parseAdvertisedRefs reads the tags from an original protocol ref
+// advertisement. Annotated tags are replaced with the commit they peel to.
+func parseAdvertisedRefs(lines []string) []gitRef {
+	refs := make([]gitRef, 0, len(lines))
+	index := make(map[string]int)
+
+	for _, line := range lines {
+		// The first ref is followed by the capabilities
+		line, _, _ = strings.Cut(line, "\x00")
+
+		commit, refName, ok := strings.Cut(line, " ")
+		if !ok || !strings.HasPrefix(refName, gitTagPrefix) {
+			continue
+		}
+
+		tagName := strings.TrimPrefix(refName, gitTagPrefix)
+		if peeled, ok := strings.CutSuffix(tagName, gitPeeledRef); ok {
+			if i, found := index[peeled]; found {
+				refs[i].Commit = commit
+			}
+
+			continue
+		}
+
+		index[tagName] = len(refs)
+		refs = append(refs, gitRef{Name: tagName, Commit: commit})
+	}
+
+	return refs
+}
+
+// readPktLines splits a pkt-line stream into its lines. Special packets such
+// as flush and delimiters are returned as empty lines.
+func readPktLines(data []byte) ([]string, error) {
+	var lines []string
+
+	for len(data) > 0 {
+		if len(data) < gitPktLenBytes {
+			return nil, fmt.Errorf("truncated pkt-line: %w", ErrScmDriver)
+		}
+
+		length, err := strconv.ParseUint(string(data[:gitPktLenBytes]), 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pkt-line length %q: %w", data[:gitPktLenBytes], ErrScmDriver)
+		}
+
+		if length <= gitPktLenBytes {
+			lines = append(lines, "")
+			data = data[gitPktLenBytes:]
+
+			continue
+		}
+
+		if int(length) > len(data) {
+			return nil, fmt.Errorf("truncated pkt-line: %w", ErrScmDriver)
+		}
+
+		line := strings.TrimSuffix(string(data[gitPktLenBytes:length]), "\n")
+		if msg, ok := strings.CutPrefix(line, "ERR "); ok {
+			return nil, fmt.Errorf("remote error %s: %w", msg, ErrScmDriver)
+		}
+
+		lines = append(lines, line)
+		data = data[length:]
+	}
+
+	return lines, nil
+}
+
+func writePktLine(buf *bytes.Buffer, line string) {
+	fmt.Fprintf(buf, "%04x%s", len(line)+gitPktLenBytes, line)
+}
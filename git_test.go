@@ -0,0 +1,171 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	gitTestCommit1 = "1111111111111111111111111111111111111111"
+	gitTestCommit2 = "2222222222222222222222222222222222222222"
+	gitTestTag2    = "3333333333333333333333333333333333333333"
+)
+
+func pktLines(lines ...string) string {
+	var buf bytes.Buffer
+	for _, line := range lines {
+		switch line {
+		case gitFlushPkt, gitDelimPkt:
+			buf.WriteString(line)
+		default:
+			writePktLine(&buf, line)
+		}
+	}
+
+	return buf.String()
+}
+
+func TestReadPktLines(t *testing.T) {
+	lines, err := readPktLines([]byte(pktLines("# service=git-upload-pack\n", gitFlushPkt, "version 2\n", gitDelimPkt, "ls-refs")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"# service=git-upload-pack", "", "version 2", "", "ls-refs"}
+	if fmt.Sprintf("%q", lines) != fmt.Sprintf("%q", expected) {
+		t.Errorf("unexpected lines %q", lines)
+	}
+}
+
+func TestReadPktLinesErrors(t *testing.T) {
+	tests := map[string]string{
+		"truncated length": "00",
+		"invalid length":   "zzzz",
+		"truncated line":   "0010abc",
+		"remote error":     pktLines("ERR access denied\n"),
+	}
+
+	for name, data := range tests {
+		_, err := readPktLines([]byte(data))
+		if !errors.Is(err, ErrScmDriver) {
+			t.Errorf("%s: expected a driver error, got %v", name, err)
+		}
+	}
+}
+
+func TestParseAdvertisedRefs(t *testing.T) {
+	lines := []string{
+		gitTestCommit1 + " HEAD\x00multi_ack side-band-64k",
+		gitTestCommit1 + " refs/heads/main",
+		gitTestCommit1 + " refs/tags/v1.0.0",
+		gitTestTag2 + " refs/tags/v2.0.0",
+		gitTestCommit2 + " refs/tags/v2.0.0^{}",
+		"",
+	}
+
+	refs := parseAdvertisedRefs(lines)
+
+	expected := []gitRef{
+		{Name: "v1.0.0", Commit: gitTestCommit1},
+		{Name: "v2.0.0", Commit: gitTestCommit2},
+	}
+	if fmt.Sprint(refs) != fmt.Sprint(expected) {
+		t.Errorf("unexpected refs %v", refs)
+	}
+}
+
+func TestGitListTagsProtocolV2(t *testing.T) {
+	var command string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/owner/repo/info/refs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("service") != gitUploadPack || r.Header.Get("Git-Protocol") != gitProtocolV2 {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(w, pktLines("# service=git-upload-pack\n", gitFlushPkt, "version 2\n", "ls-refs=unborn\n", gitFlushPkt))
+	})
+	mux.HandleFunc("/owner/repo/git-upload-pack", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		command = string(body)
+
+		fmt.Fprint(w, pktLines(
+			gitTestCommit1+" refs/tags/v1.0.0\n",
+			gitTestTag2+" refs/tags/v2.0.0 peeled:"+gitTestCommit2+"\n",
+			gitTestCommit1+" refs/tags/nightly\n",
+			gitFlushPkt,
+		))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewGitClient(server.URL, "", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags, resp, err := client.ListTags(context.Background(), "owner", "repo", ListOptions{PerPage: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(command, "command=ls-refs") || !strings.Contains(command, "ref-prefix refs/tags/") {
+		t.Errorf("unexpected command %q", command)
+	}
+
+	if len(tags) != 2 || tags[0].Tag != "v2.0.0" || tags[0].Commit != gitTestCommit2 || tags[1].Tag != "v1.0.0" {
+		t.Errorf("unexpected tags %v", tags)
+	}
+	if resp.NextPage != 2 {
+		t.Errorf("expected next page 2, got %d", resp.NextPage)
+	}
+
+	tags, resp, err = client.ListTags(context.Background(), "owner", "repo", ListOptions{Page: 2, PerPage: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 1 || tags[0].Tag != "nightly" || resp.NextPage != 0 {
+		t.Errorf("unexpected last page %v %v", tags, resp)
+	}
+}
+
+func TestGitListTagsProtocolV0(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, pktLines(
+			"# service=git-upload-pack\n",
+			gitFlushPkt,
+			gitTestCommit1+" HEAD\x00multi_ack\n",
+			gitTestTag2+" refs/tags/v2.0.0\n",
+			gitTestCommit2+" refs/tags/v2.0.0^{}\n",
+			gitFlushPkt,
+		))
+	}))
+	defer server.Close()
+
+	client, err := NewGitClient(server.URL+"/repo.git", "", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags, _, err := client.ListTags(context.Background(), "", "", ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tags) != 1 || tags[0].Tag != "v2.0.0" || tags[0].Commit != gitTestCommit2 {
+		t.Errorf("unexpected tags %v", tags)
+	}
+}
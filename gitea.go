@@ -0,0 +1,312 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// giteaMaxResponseItemsDefault is the default value of MAX_RESPONSE_ITEMS
+// within a Gitea or Forgejo instance.
+const giteaMaxResponseItemsDefault = 50
+
+type (
+	giteaClient struct {
+		client  *http.Client
+		baseURL *url.URL
+		webURL  string
+		token   string
+
+		maxItemsOnce sync.Once
+		maxItems     int
+	}
+
+	giteaRelease struct {
+		TagName         string    `json:"tag_name"`
+		TargetCommitish string    `json:"target_commitish"`
+		HTMLURL         string    `json:"html_url"`
+		PublishedAt     time.Time `json:"published_at"`
+		Draft           bool      `json:"draft"`
+		Prerelease      bool      `json:"prerelease"`
+	}
+
+	giteaTag struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA     string    `json:"sha"`
+			Created time.Time `json:"created"`
+		} `json:"commit"`
+	}
+
+	giteaAPISettings struct {
+		MaxResponseItems int `json:"max_response_items"`
+	}
+)
+
+func init() {
+	RegisterDriver(DriverGitea, func(config DriverConfig) (Client, error) {
+		return NewGiteaClient(config.URI, config.Token, config.HTTPClient)
+	})
+}
+
+func NewGitea(uri, token string) (Client, error) {
+	return NewGiteaClient(uri, token, http.DefaultClient)
+}
+
+func NewGiteaClient(uri, token string, cl *http.Client) (Client, error) {
+	// Parse the url
+	giteaURL, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse gitea link: %w", err)
+	}
+
+	// Get base url for API
+	relBaseURL, _ := url.Parse("./api/v1/")
+	baseURL := giteaURL.ResolveReference(relBaseURL)
+
+	logrus.WithFields(logrus.Fields{
+		"gitea-url": giteaURL.String(),
+		"base-url":  baseURL.String(),
+	}).Debug("connecting to gitea instance")
+
+	return &giteaClient{client: cl, baseURL: baseURL, webURL: strings.TrimSuffix(giteaURL.String(), "/"), token: token}, nil
+}
+
+func (c *giteaClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	logrus.WithFields(logrus.Fields{
+		"owner":    owner,
+		"name":     name,
+		"page":     opt.Page,
+		"per-page": opt.PerPage,
+	}).Debug("listing gitea releases")
+
+	var releases []giteaRelease
+
+	resp, err := c.listPages(ctx, fmt.Sprintf("repos/%s/%s/releases", url.PathEscape(owner), url.PathEscape(name)), opt, func(page []byte) (int, error) {
+		var items []giteaRelease
+		if err := json.Unmarshal(page, &items); err != nil {
+			return 0, err
+		}
+
+		releases = append(releases, items...)
+
+		return len(items), nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting releases from repository %s/%s: %w", owner, name, err)
+	}
+
+	r := make([]Release, 0, len(releases))
+
+	for _, release := range releases {
+		tagName := release.TagName
+
+		// The target is a branch unless the release was created from a commit
+		commit := commitOrEmpty(release.TargetCommitish)
+
+		logrus.WithFields(logrus.Fields{
+			"tag":    tagName,
+			"commit": commit,
+		}).Debug("found release")
+
+		r = append(r, Release{
+			Tag:         tagName,
+			SemVer:      generateVersion(tagName, versionMatcher),
+			Commit:      commit,
+			URL:         release.HTMLURL,
+			PublishedAt: release.PublishedAt,
+			Draft:       release.Draft,
+			Prerelease:  release.Prerelease,
+		})
+	}
+
+	return r, resp, nil
+}
+
+func (c *giteaClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	logrus.WithFields(logrus.Fields{
+		"owner":    owner,
+		"name":     name,
+		"page":     opt.Page,
+		"per-page": opt.PerPage,
+	}).Debug("listing gitea tags")
+
+	var tags []giteaTag
+
+	resp, err := c.listPages(ctx, fmt.Sprintf("repos/%s/%s/tags", url.PathEscape(owner), url.PathEscape(name)), opt, func(page []byte) (int, error) {
+		var items []giteaTag
+		if err := json.Unmarshal(page, &items); err != nil {
+			return 0, err
+		}
+
+		tags = append(tags, items...)
+
+		return len(items), nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting tags from repository %s/%s: %w", owner, name, err)
+	}
+
+	r := make([]Release, 0, len(tags))
+
+	for _, tag := range tags {
+		tagName := tag.Name
+
+		logrus.WithFields(logrus.Fields{
+			"tag":    tagName,
+			"commit": tag.Commit.SHA,
+		}).Debug("found tag")
+
+		r = append(r, Release{
+			Tag:         tagName,
+			SemVer:      generateVersion(tagName, versionMatcher),
+			Commit:      tag.Commit.SHA,
+			URL:         fmt.Sprintf("%s/%s/%s/releases/tag/%s", c.webURL, owner, name, url.PathEscape(tagName)),
+			PublishedAt: tag.Commit.Created,
+		})
+	}
+
+	return r, resp, nil
+}
+
+// ResolveTag looks up the commit of the tag.
+func (c *giteaClient) ResolveTag(ctx context.Context, owner, name, tag string) (string, error) {
+	body, err := c.get(ctx, fmt.Sprintf("repos/%s/%s/tags/%s", url.PathEscape(owner), url.PathEscape(name), url.PathEscape(tag)), nil)
+	if err != nil {
+		return "", fmt.Errorf("error getting tag %s from repository %s/%s: %w", tag, owner, name, err)
+	}
+
+	var t giteaTag
+	if err = json.Unmarshal(body, &t); err != nil {
+		return "", fmt.Errorf("could not decode gitea response: %w", err)
+	}
+
+	if !commitMatcher.MatchString(t.Commit.SHA) {
+		return "", fmt.Errorf("tag %s of repository %s/%s does not point to a commit: %w", tag, owner, name, ErrScmDriver)
+	}
+
+	return t.Commit.SHA, nil
+}
+
+// listPages requests the window of results described by opt.
+//
+// Gitea paginates with page and limit parameters but silently clamps limit to
+// the MAX_RESPONSE_ITEMS of the instance. When the requested page size is
+// larger than that the window is assembled from several smaller pages so the
+// caller always sees pages of the size it asked for.
+func (c *giteaClient) listPages(ctx context.Context, path string, opt ListOptions, decode func([]byte) (int, error)) (*Response, error) {
+	perPage := opt.PerPage
+	if perPage <= 0 {
+		perPage = perPageDefault
+	}
+
+	page := opt.Page
+	if page <= 0 {
+		page = startingPage
+	}
+
+	limit := giteaPageLimit(perPage, c.maxResponseItems(ctx))
+	first := (page-1)*perPage/limit + 1
+	last := page * perPage / limit
+
+	for giteaPage := first; giteaPage <= last; giteaPage++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(giteaPage))
+		query.Set("limit", strconv.Itoa(limit))
+
+		body, err := c.get(ctx, path, query)
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := decode(body)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode gitea response: %w", err)
+		}
+
+		if count < limit {
+			return &Response{}, nil
+		}
+	}
+
+	return &Response{NextPage: page + 1}, nil
+}
+
+// maxResponseItems queries the maximum page size of the instance.
+func (c *giteaClient) maxResponseItems(ctx context.Context) int {
+	c.maxItemsOnce.Do(func() {
+		c.maxItems = giteaMaxResponseItemsDefault
+
+		body, err := c.get(ctx, "settings/api", nil)
+		if err != nil {
+			logrus.WithError(err).Debug("could not query gitea api settings")
+
+			return
+		}
+
+		var settings giteaAPISettings
+		if err = json.Unmarshal(body, &settings); err != nil || settings.MaxResponseItems <= 0 {
+			logrus.WithError(err).Debug("could not read gitea api settings")
+
+			return
+		}
+
+		c.maxItems = settings.MaxResponseItems
+	})
+
+	logrus.WithField("max-response-items", c.maxItems).Debug("gitea page size")
+
+	return c.maxItems
+}
+
+func (c *giteaClient) get(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	rel, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := c.baseURL.ResolveReference(rel)
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	return doRequest(c.client, req)
+}
+
+// giteaPageLimit determines the largest page size that is accepted by the
+// instance and evenly divides the requested page size.
+func giteaPageLimit(perPage, maxItems int) int {
+	if perPage <= maxItems {
+		return perPage
+	}
+
+	for limit := maxItems; limit > 1; limit-- {
+		if perPage%limit == 0 {
+			return limit
+		}
+	}
+
+	return 1
+}
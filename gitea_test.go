@@ -0,0 +1,182 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// newGiteaServer serves the tags v1.0.0 to v1.<count-1>.0, greatest first,
+// clamping the page size to maxItems as an instance does.
+func newGiteaServer(t *testing.T, count, maxItems int) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/settings/api", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"max_response_items": %d}`, maxItems)
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/tags", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.RawQuery)
+		mu.Unlock()
+
+		if r.Header.Get("Authorization") != "token secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		limit = min(limit, maxItems)
+
+		tags := []map[string]interface{}{}
+		for i := (page - 1) * limit; i < page*limit && i < count; i++ {
+			tags = append(tags, map[string]interface{}{
+				"name":   fmt.Sprintf("v1.%d.0", count-1-i),
+				"commit": map[string]string{"sha": fmt.Sprintf("%040d", count-1-i)},
+			})
+		}
+
+		_ = json.NewEncoder(w).Encode(tags)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestGiteaListTagsAssemblesPages(t *testing.T) {
+	server, requests := newGiteaServer(t, 10, 2)
+
+	client, err := NewGiteaClient(server.URL, "secret", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags, resp, err := client.ListTags(context.Background(), "owner", "repo", ListOptions{Page: 2, PerPage: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, tag := range tags {
+		names = append(names, tag.Tag)
+	}
+	if fmt.Sprint(names) != "[v1.5.0 v1.4.0 v1.3.0 v1.2.0]" {
+		t.Errorf("unexpected tags %v", names)
+	}
+	if tags[0].SemVer == nil || tags[0].SemVer.String() != "1.5.0" {
+		t.Errorf("unexpected version %v", tags[0].SemVer)
+	}
+	if tags[0].Commit != fmt.Sprintf("%040d", 5) {
+		t.Errorf("unexpected commit %s", tags[0].Commit)
+	}
+	if resp.NextPage != 3 {
+		t.Errorf("expected next page 3, got %d", resp.NextPage)
+	}
+	if fmt.Sprint(*requests) != "[limit=2&page=3 limit=2&page=4]" {
+		t.Errorf("unexpected requests %v", *requests)
+	}
+}
+
+func TestGiteaListTagsLastPage(t *testing.T) {
+	server, _ := newGiteaServer(t, 5, 50)
+
+	client, err := NewGiteaClient(server.URL, "secret", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags, resp, err := client.ListTags(context.Background(), "owner", "repo", ListOptions{PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tags) != 5 {
+		t.Errorf("expected 5 tags, got %d", len(tags))
+	}
+	if resp.NextPage != 0 {
+		t.Errorf("expected no next page, got %d", resp.NextPage)
+	}
+}
+
+func TestGiteaListTagsError(t *testing.T) {
+	server, _ := newGiteaServer(t, 5, 50)
+
+	client, err := NewGiteaClient(server.URL, "wrong", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.ListTags(context.Background(), "owner", "repo", ListOptions{}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestGiteaPageLimit(t *testing.T) {
+	tests := []struct {
+		perPage, maxItems, limit int
+	}{
+		{30, 50, 30},
+		{100, 50, 50},
+		{100, 30, 25},
+		{7, 5, 1},
+	}
+
+	for _, test := range tests {
+		if limit := giteaPageLimit(test.perPage, test.maxItems); limit != test.limit {
+			t.Errorf("giteaPageLimit(%d, %d) = %d, expected %d", test.perPage, test.maxItems, limit, test.limit)
+		}
+	}
+}
+
+func TestGiteaReleaseCommit(t *testing.T) {
+	commit := fmt.Sprintf("%040d", 7)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/owner/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"tag_name": "v1.1.0", "target_commitish": "main"}, {"tag_name": "v1.0.0", "target_commitish": %q}]`, commit)
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/tags/v1.1.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name": "v1.1.0", "commit": {"sha": %q}}`, commit)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewGiteaClient(server.URL, "", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	releases, _, err := client.ListReleases(context.Background(), "owner", "repo", ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A branch is not a commit
+	if releases[0].Commit != "" || releases[1].Commit != commit {
+		t.Errorf("unexpected commits %q %q", releases[0].Commit, releases[1].Commit)
+	}
+
+	resolved, err := client.(TagResolver).ResolveTag(context.Background(), "owner", "repo", "v1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved != commit {
+		t.Errorf("unexpected commit %s", resolved)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/google/go-github/v75/github"
 	"github.com/sirupsen/logrus"
@@ -17,6 +18,22 @@ import (
 
 type githubClient struct {
 	client *github.Client
+	webURL string
+}
+
+func init() {
+	RegisterDriver(DriverGitHub, func(config DriverConfig) (Client, error) {
+		graphQL, err := config.Bool("graphql")
+		if err != nil {
+			return nil, err
+		}
+
+		if graphQL {
+			return NewGitHubGraphQLClient(config.URI, config.Token, config.HTTPClient)
+		}
+
+		return NewGitHubClient(config.URI, config.Token, config.HTTPClient)
+	})
 }
 
 func NewGitHub(uri, token string) (Client, error) {
@@ -53,10 +70,10 @@ func NewGitHubClient(uri, token string, cl *http.Client) (Client, error) {
 		"upload-url": client.BaseURL.String(),
 	}).Debug("connecting to github instance")
 
-	return &githubClient{client: client}, nil
+	return &githubClient{client: client, webURL: strings.TrimSuffix(githubURL.String(), "/")}, nil
 }
 
-func (c *githubClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, error) {
+func (c *githubClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
 	ghOpts := &github.ListOptions{
 		Page:    opt.Page,
 		PerPage: opt.PerPage,
@@ -69,9 +86,9 @@ func (c *githubClient) ListReleases(ctx context.Context, owner, name string, opt
 		"per-page": ghOpts.PerPage,
 	}).Debug("listing github releases")
 
-	releases, _, err := c.client.Repositories.ListReleases(ctx, owner, name, ghOpts)
+	releases, resp, err := c.client.Repositories.ListReleases(ctx, owner, name, ghOpts)
 	if err != nil {
-		return nil, fmt.Errorf("error getting releases from repository %s/%s: %w", owner, name, err)
+		return nil, nil, fmt.Errorf("error getting releases from repository %s/%s: %w", owner, name, err)
 	}
 
 	r := make([]Release, 0, ghOpts.PerPage)
@@ -79,18 +96,29 @@ func (c *githubClient) ListReleases(ctx context.Context, owner, name string, opt
 	for _, release := range releases {
 		tagName := release.GetTagName()
 
+		// The target is a branch unless the release was created from a commit
+		commit := commitOrEmpty(release.GetTargetCommitish())
+
 		logrus.WithFields(logrus.Fields{
 			"tag":    tagName,
-			"commit": release.GetTargetCommitish(),
+			"commit": commit,
 		}).Debug("found release")
 
-		r = append(r, Release{Tag: tagName, SemVer: generateVersion(tagName, versionMatcher)})
+		r = append(r, Release{
+			Tag:         tagName,
+			SemVer:      generateVersion(tagName, versionMatcher),
+			Commit:      commit,
+			URL:         release.GetHTMLURL(),
+			PublishedAt: release.GetPublishedAt().Time,
+			Draft:       release.GetDraft(),
+			Prerelease:  release.GetPrerelease(),
+		})
 	}
 
-	return r, nil
+	return r, &Response{NextPage: resp.NextPage}, nil
 }
 
-func (c *githubClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, error) {
+func (c *githubClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
 	ghOpts := &github.ListOptions{
 		Page:    opt.Page,
 		PerPage: opt.PerPage,
@@ -103,9 +131,9 @@ func (c *githubClient) ListTags(ctx context.Context, owner, name string, opt Lis
 		"per-page": ghOpts.PerPage,
 	}).Debug("listing github tags")
 
-	tags, _, err := c.client.Repositories.ListTags(ctx, owner, name, ghOpts)
+	tags, resp, err := c.client.Repositories.ListTags(ctx, owner, name, ghOpts)
 	if err != nil {
-		return nil, fmt.Errorf("error getting tags from repository %s/%s: %w", owner, name, err)
+		return nil, nil, fmt.Errorf("error getting tags from repository %s/%s: %w", owner, name, err)
 	}
 
 	r := make([]Release, 0, ghOpts.PerPage)
@@ -118,8 +146,37 @@ func (c *githubClient) ListTags(ctx context.Context, owner, name string, opt Lis
 			"commit": tag.GetCommit().GetSHA(),
 		}).Debug("found tag")
 
-		r = append(r, Release{Tag: tagName, SemVer: generateVersion(tagName, versionMatcher)})
+		r = append(r, Release{
+			Tag:    tagName,
+			SemVer: generateVersion(tagName, versionMatcher),
+			Commit: tag.GetCommit().GetSHA(),
+			URL:    fmt.Sprintf("%s/%s/%s/releases/tag/%s", c.webURL, owner, name, url.PathEscape(tagName)),
+		})
+	}
+
+	return r, &Response{NextPage: resp.NextPage}, nil
+}
+
+// ResolveTag looks up the commit of the tag, peeling any annotated tags.
+func (c *githubClient) ResolveTag(ctx context.Context, owner, name, tag string) (string, error) {
+	ref, _, err := c.client.Git.GetRef(ctx, owner, name, "tags/"+tag)
+	if err != nil {
+		return "", fmt.Errorf("error getting tag %s from repository %s/%s: %w", tag, owner, name, err)
+	}
+
+	object := ref.GetObject()
+	for object.GetType() == "tag" {
+		annotated, _, err := c.client.Git.GetTag(ctx, owner, name, object.GetSHA())
+		if err != nil {
+			return "", fmt.Errorf("error getting tag %s from repository %s/%s: %w", tag, owner, name, err)
+		}
+
+		object = annotated.GetObject()
+	}
+
+	if object.GetType() != "commit" {
+		return "", fmt.Errorf("tag %s of repository %s/%s does not point to a commit: %w", tag, owner, name, ErrScmDriver)
 	}
 
-	return r, nil
+	return object.GetSHA(), nil
 }
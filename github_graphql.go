@@ -0,0 +1,404 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+const (
+	githubGraphQLURL       = "https://api.github.com/graphql"
+	githubGraphQLPageSize  = 100
+	githubGraphQLBatchSize = 20
+)
+
+type (
+	// Repository identifies the releases or tags of a repository.
+	Repository struct {
+		Owner string
+		Name  string
+		Tags  bool
+	}
+
+	// Prefetcher is implemented by clients that can retrieve the first page of
+	// several repositories in a single request. The prefetched page is
+	// returned by the next request for the first page of the repository.
+	Prefetcher interface {
+		Prefetch(ctx context.Context, repos []Repository) error
+	}
+
+	githubGraphQLClient struct {
+		client   *http.Client
+		endpoint string
+		webURL   string
+		rest     Client
+
+		mu         sync.Mutex
+		prefetched map[Repository]githubGraphQLPage
+	}
+
+	githubGraphQLPage struct {
+		releases []Release
+		resp     *Response
+	}
+
+	githubGraphQLPageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	}
+
+	githubGraphQLRepository struct {
+		Releases *struct {
+			Nodes []struct {
+				TagName      string     `json:"tagName"`
+				URL          string     `json:"url"`
+				PublishedAt  *time.Time `json:"publishedAt"`
+				IsDraft      bool       `json:"isDraft"`
+				IsPrerelease bool       `json:"isPrerelease"`
+				TagCommit    *struct {
+					Oid string `json:"oid"`
+				} `json:"tagCommit"`
+			} `json:"nodes"`
+			PageInfo githubGraphQLPageInfo `json:"pageInfo"`
+		} `json:"releases"`
+		Refs *struct {
+			Nodes []struct {
+				Name   string              `json:"name"`
+				Target githubGraphQLTarget `json:"target"`
+			} `json:"nodes"`
+			PageInfo githubGraphQLPageInfo `json:"pageInfo"`
+		} `json:"refs"`
+	}
+
+	githubGraphQLTarget struct {
+		Typename      string     `json:"__typename"`
+		Oid           string     `json:"oid"`
+		CommittedDate *time.Time `json:"committedDate"`
+		Tagger        *struct {
+			Date *time.Time `json:"date"`
+		} `json:"tagger"`
+		Target *githubGraphQLTarget `json:"target"`
+	}
+
+	githubGraphQLResponse struct {
+		Data   map[string]*githubGraphQLRepository `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+)
+
+// NewGitHubGraphQL creates a GitHub client that uses the GraphQL API.
+//
+// Releases and tags are retrieved in pages of 100 and the first page of
+// several repositories can be retrieved at once through Prefetch. The REST
+// API is used when a query fails before any page has been retrieved.
+func NewGitHubGraphQL(uri, token string) (Client, error) {
+	return NewGitHubGraphQLClient(uri, token, http.DefaultClient)
+}
+
+func NewGitHubGraphQLClient(uri, token string, cl *http.Client) (Client, error) {
+	rest, err := NewGitHubClient(uri, token, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the url
+	githubURL, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse github link: %w", err)
+	}
+
+	// Get the endpoint for the API
+	endpoint := githubGraphQLURL
+	if githubURL.Hostname() != "github.com" {
+		relEndpoint, _ := url.Parse("./api/graphql")
+		endpoint = githubURL.ResolveReference(relEndpoint).String()
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"github-url": githubURL.String(),
+		"endpoint":   endpoint,
+	}).Debug("connecting to github graphql api")
+
+	// Create the client
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	tc := oauth2.NewClient(
+		context.WithValue(context.Background(), oauth2.HTTPClient, cl),
+		ts,
+	)
+
+	return &githubGraphQLClient{
+		client:     tc,
+		endpoint:   endpoint,
+		webURL:     strings.TrimSuffix(githubURL.String(), "/"),
+		rest:       rest,
+		prefetched: make(map[Repository]githubGraphQLPage),
+	}, nil
+}
+
+func (c *githubGraphQLClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	return c.list(ctx, Repository{Owner: owner, Name: name}, opt)
+}
+
+func (c *githubGraphQLClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	return c.list(ctx, Repository{Owner: owner, Name: name, Tags: true}, opt)
+}
+
+// Prefetch retrieves the first page of each repository in batches.
+func (c *githubGraphQLClient) Prefetch(ctx context.Context, repos []Repository) error {
+	for start := 0; start < len(repos); start += githubGraphQLBatchSize {
+		batch := repos[start:min(start+githubGraphQLBatchSize, len(repos))]
+
+		logrus.WithField("repositories", len(batch)).Debug("prefetching github releases")
+
+		pages, err := c.query(ctx, batch, "")
+		if err != nil {
+			return fmt.Errorf("could not prefetch releases: %w", err)
+		}
+
+		c.mu.Lock()
+		for repo, page := range pages {
+			c.prefetched[repo] = page
+		}
+		c.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (c *githubGraphQLClient) list(ctx context.Context, repo Repository, opt ListOptions) ([]Release, *Response, error) {
+	logrus.WithFields(logrus.Fields{
+		"owner":  repo.Owner,
+		"name":   repo.Name,
+		"tags":   repo.Tags,
+		"cursor": opt.Cursor,
+	}).Debug("listing github releases with graphql")
+
+	// Continue with the REST API when it was used for the first page
+	if opt.Cursor == "" && opt.Page > startingPage {
+		return c.listREST(ctx, repo, opt)
+	}
+
+	if opt.Cursor == "" {
+		c.mu.Lock()
+		page, ok := c.prefetched[repo]
+		delete(c.prefetched, repo)
+		c.mu.Unlock()
+
+		if ok {
+			return page.releases, page.resp, nil
+		}
+	}
+
+	pages, err := c.query(ctx, []Repository{repo}, opt.Cursor)
+	if err != nil {
+		if opt.Cursor != "" {
+			return nil, nil, fmt.Errorf("error getting releases from repository %s/%s: %w", repo.Owner, repo.Name, err)
+		}
+
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"owner": repo.Owner,
+			"name":  repo.Name,
+		}).Warn("falling back to the github rest api")
+
+		return c.listREST(ctx, repo, opt)
+	}
+
+	page, ok := pages[repo]
+	if !ok {
+		return nil, nil, fmt.Errorf("could not find repository %s/%s: %w", repo.Owner, repo.Name, ErrScmDriver)
+	}
+
+	return page.releases, page.resp, nil
+}
+
+func (c *githubGraphQLClient) listREST(ctx context.Context, repo Repository, opt ListOptions) ([]Release, *Response, error) {
+	if repo.Tags {
+		return c.rest.ListTags(ctx, repo.Owner, repo.Name, opt)
+	}
+
+	return c.rest.ListReleases(ctx, repo.Owner, repo.Name, opt)
+}
+
+// query retrieves a page of each repository. Each repository is given an
+// alias within the query so the results can be matched up.
+func (c *githubGraphQLClient) query(ctx context.Context, repos []Repository, after string) (map[Repository]githubGraphQLPage, error) {
+	var sb strings.Builder
+
+	sb.WriteString("query {\n")
+	for i, repo := range repos {
+		fmt.Fprintf(&sb, "r%d: repository(owner: %s, name: %s) {\n", i, graphQLString(repo.Owner), graphQLString(repo.Name))
+
+		args := fmt.Sprintf("first: %d", githubGraphQLPageSize)
+		if after != "" {
+			args += ", after: " + graphQLString(after)
+		}
+
+		if repo.Tags {
+			fmt.Fprintf(&sb, `refs(refPrefix: "refs/tags/", %s, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) {
+nodes { name target { __typename oid ... on Commit { committedDate } ... on Tag { tagger { date } target { __typename oid ... on Commit { committedDate } } } } }
+pageInfo { hasNextPage endCursor }
+}
+`, args)
+		} else {
+			fmt.Fprintf(&sb, `releases(%s, orderBy: {field: CREATED_AT, direction: DESC}) {
+nodes { tagName url publishedAt isDraft isPrerelease tagCommit { oid } }
+pageInfo { hasNextPage endCursor }
+}
+`, args)
+		}
+
+		sb.WriteString("}\n")
+	}
+	sb.WriteString("}\n")
+
+	body, err := json.Marshal(map[string]string{"query": sb.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	// The query only reads so it can be retried
+	req, err := http.NewRequestWithContext(WithRetryable(ctx), http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	b, err := doRequest(c.client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp githubGraphQLResponse
+	if err = json.Unmarshal(b, &resp); err != nil {
+		return nil, fmt.Errorf("could not decode github graphql response: %w", err)
+	}
+
+	// Errors for individual repositories leave the rest of the data intact
+	for _, e := range resp.Errors {
+		logrus.WithField("error", e.Message).Debug("github graphql error")
+	}
+
+	pages := make(map[Repository]githubGraphQLPage, len(repos))
+	for i, repo := range repos {
+		data := resp.Data[fmt.Sprintf("r%d", i)]
+		if data == nil {
+			continue
+		}
+
+		pages[repo] = c.page(repo, data)
+	}
+
+	if len(pages) == 0 && len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("github graphql query failed: %s: %w", resp.Errors[0].Message, ErrScmDriver)
+	}
+
+	return pages, nil
+}
+
+func (c *githubGraphQLClient) page(repo Repository, data *githubGraphQLRepository) githubGraphQLPage {
+	var page githubGraphQLPage
+	var pageInfo githubGraphQLPageInfo
+
+	if repo.Tags && data.Refs != nil {
+		pageInfo = data.Refs.PageInfo
+
+		for _, node := range data.Refs.Nodes {
+			commit, publishedAt := node.Target.commit()
+
+			logrus.WithFields(logrus.Fields{
+				"tag":    node.Name,
+				"commit": commit,
+			}).Debug("found tag")
+
+			page.releases = append(page.releases, Release{
+				Tag:         node.Name,
+				SemVer:      generateVersion(node.Name, versionMatcher),
+				Commit:      commit,
+				URL:         fmt.Sprintf("%s/%s/%s/releases/tag/%s", c.webURL, repo.Owner, repo.Name, url.PathEscape(node.Name)),
+				PublishedAt: publishedAt,
+			})
+		}
+	} else if !repo.Tags && data.Releases != nil {
+		pageInfo = data.Releases.PageInfo
+
+		for _, node := range data.Releases.Nodes {
+			release := Release{
+				Tag:        node.TagName,
+				SemVer:     generateVersion(node.TagName, versionMatcher),
+				URL:        node.URL,
+				Draft:      node.IsDraft,
+				Prerelease: node.IsPrerelease,
+			}
+			if node.TagCommit != nil {
+				release.Commit = node.TagCommit.Oid
+			}
+			if node.PublishedAt != nil {
+				release.PublishedAt = *node.PublishedAt
+			}
+
+			logrus.WithFields(logrus.Fields{
+				"tag":    release.Tag,
+				"commit": release.Commit,
+			}).Debug("found release")
+
+			page.releases = append(page.releases, release)
+		}
+	}
+
+	page.resp = &Response{}
+	if pageInfo.HasNextPage {
+		page.resp.NextCursor = pageInfo.EndCursor
+	}
+
+	return page
+}
+
+// commit returns the commit the tag points to and when it was made, peeling
+// any annotated tag.
+func (t githubGraphQLTarget) commit() (string, time.Time) {
+	var date time.Time
+
+	if t.Typename == "Tag" {
+		if t.Tagger != nil && t.Tagger.Date != nil {
+			date = *t.Tagger.Date
+		}
+
+		if t.Target != nil {
+			commit, committedDate := t.Target.commit()
+			if date.IsZero() {
+				date = committedDate
+			}
+
+			return commit, date
+		}
+	}
+
+	if t.CommittedDate != nil {
+		date = *t.CommittedDate
+	}
+
+	return t.Oid, date
+}
+
+// graphQLString quotes the value for use within a query.
+func graphQLString(s string) string {
+	b, _ := json.Marshal(s)
+
+	return string(b)
+}
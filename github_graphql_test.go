@@ -0,0 +1,289 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	graphQLRepositoryMatcher = regexp.MustCompile(`(r\d+): repository\(owner: "((?:[^"\\]|\\.)*)", name: "((?:[^"\\]|\\.)*)"\)`)
+	graphQLCursorMatcher     = regexp.MustCompile(`after: "(\d+)"`)
+)
+
+// graphQLServer answers queries for the releases v1.<count-1>.0 to v1.0.0 of
+// every repository in pages of 100, recording the queries made. A repository
+// named missing is reported as an error.
+type graphQLServer struct {
+	count int
+
+	mu      sync.Mutex
+	queries []string
+	rest    []string
+}
+
+func newGraphQLServer(t *testing.T, count int) (*httptest.Server, *graphQLServer) {
+	t.Helper()
+
+	s := &graphQLServer{count: count}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	return server, s
+}
+
+func (s *graphQLServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/graphql" {
+		s.mu.Lock()
+		s.rest = append(s.rest, r.URL.Path)
+		s.mu.Unlock()
+
+		_, _ = w.Write([]byte(`[{"tag_name": "v9.0.0", "target_commitish": "main"}]`))
+		return
+	}
+
+	if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer secret" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var body struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.queries = append(s.queries, body.Query)
+	s.mu.Unlock()
+
+	// The cursor is the index of the first release of the next page
+	start := 0
+	if match := graphQLCursorMatcher.FindStringSubmatch(body.Query); match != nil {
+		_, _ = fmt.Sscan(match[1], &start)
+	}
+
+	data := map[string]interface{}{}
+	var errors []map[string]string
+	for _, match := range graphQLRepositoryMatcher.FindAllStringSubmatch(body.Query, -1) {
+		if match[3] == "missing" {
+			data[match[1]] = nil
+			errors = append(errors, map[string]string{"message": "Could not resolve to a Repository"})
+			continue
+		}
+
+		var nodes []map[string]interface{}
+		for i := start; i < start+githubGraphQLPageSize && i < s.count; i++ {
+			tag := fmt.Sprintf("v1.%d.0", s.count-1-i)
+			commit := fmt.Sprintf("%040d", s.count-1-i)
+
+			if strings.Contains(body.Query, "refs(") {
+				// Odd tags are annotated
+				target := map[string]interface{}{"__typename": "Commit", "oid": commit, "committedDate": "2026-01-02T00:00:00Z"}
+				if i%2 == 1 {
+					target = map[string]interface{}{"__typename": "Tag", "oid": "tag", "target": target}
+				}
+				nodes = append(nodes, map[string]interface{}{"name": tag, "target": target})
+			} else {
+				nodes = append(nodes, map[string]interface{}{"tagName": tag, "url": "https://example.com/" + tag, "isDraft": i == 0, "tagCommit": map[string]string{"oid": commit}})
+			}
+		}
+
+		end := start + githubGraphQLPageSize
+		pageInfo := map[string]interface{}{"hasNextPage": end < s.count, "endCursor": fmt.Sprint(end)}
+		connection := map[string]interface{}{"nodes": nodes, "pageInfo": pageInfo}
+		if strings.Contains(body.Query, "refs(") {
+			data[match[1]] = map[string]interface{}{"refs": connection}
+		} else {
+			data[match[1]] = map[string]interface{}{"releases": connection}
+		}
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "errors": errors})
+}
+
+func TestGitHubGraphQLQuery(t *testing.T) {
+	server, s := newGraphQLServer(t, 150)
+
+	client, err := NewGitHubGraphQLClient(server.URL+"/", "secret", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	releases, resp, err := client.ListReleases(context.Background(), "owner", `re"po`, ListOptions{Page: 1, PerPage: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(releases) != 100 || releases[0].Tag != "v1.149.0" || !releases[0].Draft || releases[0].Commit != fmt.Sprintf("%040d", 149) {
+		t.Errorf("unexpected releases %+v", releases[0])
+	}
+	if resp.NextCursor != "100" {
+		t.Errorf("unexpected cursor %q", resp.NextCursor)
+	}
+
+	// Values are quoted within the query
+	for _, expected := range []string{
+		`r0: repository(owner: "owner", name: "re\"po")`,
+		`releases(first: 100, orderBy: {field: CREATED_AT, direction: DESC})`,
+		`pageInfo { hasNextPage endCursor }`,
+	} {
+		if !strings.Contains(s.queries[0], expected) {
+			t.Errorf("expected %s within the query\n%s", expected, s.queries[0])
+		}
+	}
+
+	_, resp, err = client.ListTags(context.Background(), "owner", "repo", ListOptions{Cursor: "100", PerPage: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.NextCursor != "" {
+		t.Errorf("expected the last page, got %q", resp.NextCursor)
+	}
+	if !strings.Contains(s.queries[1], `refs(refPrefix: "refs/tags/", first: 100, after: "100", orderBy: {field: TAG_COMMIT_DATE, direction: DESC})`) {
+		t.Errorf("unexpected query\n%s", s.queries[1])
+	}
+}
+
+func TestGitHubGraphQLPaging(t *testing.T) {
+	server, s := newGraphQLServer(t, 250)
+
+	client, err := NewGitHubGraphQLClient(server.URL+"/", "secret", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tags []Release
+	for release, err := range Releases(client, ListReleaseOptions{Owner: "owner", Repo: "repo", Tags: true}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tags = append(tags, release)
+	}
+
+	if len(tags) != 250 || tags[0].Tag != "v1.249.0" || tags[249].Tag != "v1.0.0" {
+		t.Fatalf("unexpected tags %d", len(tags))
+	}
+	if len(s.queries) != 3 || len(s.rest) != 0 {
+		t.Errorf("expected 3 queries, got %d and %d rest requests", len(s.queries), len(s.rest))
+	}
+
+	// Annotated tags are peeled to the commit
+	if tags[1].Commit != fmt.Sprintf("%040d", 248) || tags[1].PublishedAt.IsZero() {
+		t.Errorf("unexpected annotated tag %+v", tags[1])
+	}
+}
+
+func TestGitHubGraphQLPrefetch(t *testing.T) {
+	server, s := newGraphQLServer(t, 5)
+
+	client, err := NewGitHubGraphQLClient(server.URL+"/", "secret", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var repos []Repository
+	for i := range 45 {
+		repos = append(repos, Repository{Owner: "owner", Name: fmt.Sprintf("repo%d", i), Tags: i%2 == 0})
+	}
+
+	if err = client.(Prefetcher).Prefetch(context.Background(), repos); err != nil {
+		t.Fatal(err)
+	}
+
+	// The repositories are queried in batches
+	var batches []int
+	for _, query := range s.queries {
+		batches = append(batches, len(graphQLRepositoryMatcher.FindAllString(query, -1)))
+	}
+	if fmt.Sprint(batches) != "[20 20 5]" {
+		t.Errorf("unexpected batches %v", batches)
+	}
+
+	// The first page of a prefetched repository is returned once
+	for range 2 {
+		tags, _, err := client.ListTags(context.Background(), "owner", "repo44", ListOptions{PerPage: 30})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tags) != 5 {
+			t.Errorf("unexpected tags %+v", tags)
+		}
+	}
+	if len(s.queries) != 4 {
+		t.Errorf("expected a single further query, got %d", len(s.queries)-3)
+	}
+}
+
+func TestGitHubGraphQLFallback(t *testing.T) {
+	server, s := newGraphQLServer(t, 5)
+
+	client, err := NewGitHubGraphQLClient(server.URL+"/", "secret", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first page falls back to the REST API
+	releases, _, err := client.ListReleases(context.Background(), "owner", "missing", ListOptions{Page: 1, PerPage: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) != 1 || releases[0].Tag != "v9.0.0" {
+		t.Errorf("unexpected releases %+v", releases)
+	}
+	if fmt.Sprint(s.rest) != "[/api/v3/repos/owner/missing/releases]" {
+		t.Errorf("unexpected rest requests %v", s.rest)
+	}
+
+	// A later page does not as the cursor is not understood by the REST API
+	if _, _, err = client.ListReleases(context.Background(), "owner", "missing", ListOptions{Cursor: "100", PerPage: 30}); err == nil {
+		t.Error("expected an error for a later page")
+	}
+	if len(s.rest) != 1 {
+		t.Errorf("unexpected rest requests %v", s.rest)
+	}
+}
+
+func TestGitHubGraphQLRetry(t *testing.T) {
+	s := &graphQLServer{count: 5}
+
+	var failed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !failed {
+			failed = true
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		s.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	retry := testRetryOptions()
+	client, err := NewClientFromDriverWithOptions(DriverGitHub, server.URL+"/", "secret", ClientOptions{Retry: &retry, GraphQL: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The query is sent again rather than falling back to the REST API
+	releases, _, err := client.ListReleases(context.Background(), "owner", "repo", ListOptions{Page: 1, PerPage: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) != 5 || len(s.queries) != 1 || len(s.rest) != 0 {
+		t.Errorf("expected a single retried query, got %d queries and %d rest requests", len(s.queries), len(s.rest))
+	}
+}
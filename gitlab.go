@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gitlab.com/gitlab-org/api/client-go"
@@ -16,6 +18,14 @@ import (
 
 type gitlabClient struct {
 	client *gitlab.Client
+	webURL string
+}
+
+func init() {
+	RegisterDriver(DriverGitLab, func(config DriverConfig) (Client, error) {
+		// Requests are already retried by the transport
+		return newGitLabClient(config.URI, config.Token, config.HTTPClient, gitlab.WithoutRetries())
+	})
 }
 
 func NewGitLab(uri, token string) (Client, error) {
@@ -23,6 +33,10 @@ func NewGitLab(uri, token string) (Client, error) {
 }
 
 func NewGitLabClient(uri, token string, cl *http.Client) (Client, error) {
+	return newGitLabClient(uri, token, cl)
+}
+
+func newGitLabClient(uri, token string, cl *http.Client, options ...gitlab.ClientOptionFunc) (Client, error) {
 	// Parse the url
 	gitlabURL, err := url.Parse(uri)
 	if err != nil {
@@ -39,19 +53,20 @@ func NewGitLabClient(uri, token string, cl *http.Client) (Client, error) {
 	}).Debug("connecting to gitlab instance")
 
 	// Create the client
-	client, err := gitlab.NewClient(
-		token,
+	options = append([]gitlab.ClientOptionFunc{
 		gitlab.WithBaseURL(baseURL.String()),
 		gitlab.WithHTTPClient(cl),
-	)
+	}, options...)
+
+	client, err := gitlab.NewClient(token, options...)
 	if err != nil {
 		return nil, fmt.Errorf("could not connect to gitlab instance %s: %w", uri, err)
 	}
 
-	return &gitlabClient{client: client}, nil
+	return &gitlabClient{client: client, webURL: strings.TrimSuffix(gitlabURL.String(), "/")}, nil
 }
 
-func (c *gitlabClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, error) {
+func (c *gitlabClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
 	glOpts := &gitlab.ListReleasesOptions{
 		ListOptions: gitlab.ListOptions{
 			Page:    int64(opt.Page),
@@ -66,9 +81,9 @@ func (c *gitlabClient) ListReleases(ctx context.Context, owner, name string, opt
 		"per-page": glOpts.PerPage,
 	}).Debug("listing gitlab releases")
 
-	releases, _, err := c.client.Releases.ListReleases(fmt.Sprintf("%s/%s", owner, name), glOpts)
+	releases, resp, err := c.client.Releases.ListReleases(fmt.Sprintf("%s/%s", owner, name), glOpts)
 	if err != nil {
-		return nil, fmt.Errorf("error getting releases from repository %s/%s: %w", owner, name, err)
+		return nil, nil, fmt.Errorf("error getting releases from repository %s/%s: %w", owner, name, err)
 	}
 
 	r := make([]Release, 0, glOpts.PerPage)
@@ -81,13 +96,22 @@ func (c *gitlabClient) ListReleases(ctx context.Context, owner, name string, opt
 			"commit": release.Commit.ID,
 		}).Debug("found release")
 
-		r = append(r, Release{Tag: tagName, SemVer: generateVersion(tagName, versionMatcher)})
+		r = append(r, Release{
+			Tag:         tagName,
+			SemVer:      generateVersion(tagName, versionMatcher),
+			Commit:      release.Commit.ID,
+			URL:         release.Links.Self,
+			PublishedAt: timeOrZero(release.ReleasedAt),
+			// GitLab has no pre-release flag but marks releases with a date in
+			// the future as upcoming
+			Prerelease: release.UpcomingRelease,
+		})
 	}
 
-	return r, nil
+	return r, &Response{NextPage: int(resp.NextPage)}, nil
 }
 
-func (c *gitlabClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, error) {
+func (c *gitlabClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
 	glOpts := &gitlab.ListTagsOptions{
 		ListOptions: gitlab.ListOptions{
 			Page:    int64(opt.Page),
@@ -102,9 +126,9 @@ func (c *gitlabClient) ListTags(ctx context.Context, owner, name string, opt Lis
 		"per-page": glOpts.PerPage,
 	}).Debug("listing gitlab tags")
 
-	tags, _, err := c.client.Tags.ListTags(fmt.Sprintf("%s/%s", owner, name), glOpts)
+	tags, resp, err := c.client.Tags.ListTags(fmt.Sprintf("%s/%s", owner, name), glOpts)
 	if err != nil {
-		return nil, fmt.Errorf("error getting tags from repository %s/%s: %w", owner, name, err)
+		return nil, nil, fmt.Errorf("error getting tags from repository %s/%s: %w", owner, name, err)
 	}
 
 	r := make([]Release, 0, glOpts.PerPage)
@@ -117,8 +141,29 @@ func (c *gitlabClient) ListTags(ctx context.Context, owner, name string, opt Lis
 			"commit": tag.Commit.ID,
 		}).Debug("found tag")
 
-		r = append(r, Release{Tag: tagName, SemVer: generateVersion(tagName, versionMatcher)})
+		release := Release{
+			Tag:         tagName,
+			SemVer:      generateVersion(tagName, versionMatcher),
+			Commit:      tag.Commit.ID,
+			URL:         fmt.Sprintf("%s/%s/%s/-/tags/%s", c.webURL, owner, name, url.PathEscape(tagName)),
+			PublishedAt: timeOrZero(tag.Commit.CommittedDate),
+		}
+
+		// Annotated tags have their own creation date
+		if tag.CreatedAt != nil {
+			release.PublishedAt = *tag.CreatedAt
+		}
+
+		r = append(r, release)
+	}
+
+	return r, &Response{NextPage: int(resp.NextPage)}, nil
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
 	}
 
-	return r, nil
+	return *t
 }
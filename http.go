@@ -0,0 +1,31 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// doRequest sends the request and returns the body of a successful response.
+func doRequest(cl *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := cl.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response from %s: %w", req.URL.Redacted(), err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %s from %s: %w", resp.Status, req.URL.Redacted(), ErrScmDriver)
+	}
+
+	return body, nil
+}
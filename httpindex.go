@@ -0,0 +1,232 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// httpIndexNamePlaceholder is replaced with the quoted repository name
+	// within a file pattern.
+	httpIndexNamePlaceholder = "{name}"
+	// httpIndexVersionGroup is the name of the group capturing the version
+	// within a file pattern.
+	httpIndexVersionGroup = "version"
+	// httpIndexPatternDefault matches source archives named <name>-<version>.
+	httpIndexPatternDefault = `^{name}-(?P<version>\d[0-9A-Za-z.+_-]*?)\.(?:tar\.(?:gz|bz2|xz|lz|lzma|zst)|tgz|tbz2|txz|zip|7z)$`
+)
+
+var hrefMatcher = regexp.MustCompile(`(?i)href\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+
+type httpIndexClient struct {
+	client  *http.Client
+	baseURL *url.URL
+	pattern string
+
+	mu    sync.Mutex
+	files map[string][]Release
+}
+
+func init() {
+	RegisterDriver(DriverHTTPIndex, func(config DriverConfig) (Client, error) {
+		pattern, err := config.String("pattern")
+		if err != nil {
+			return nil, err
+		}
+
+		return NewHTTPIndexClient(config.URI, pattern, config.HTTPClient)
+	})
+}
+
+// NewHTTPIndex creates a client for releases published as files within a
+// directory index, such as an Apache or nginx autoindex page.
+//
+// The index is located at <uri>/<owner>/ where an empty owner is omitted. File
+// names within the index are matched against the pattern, which must capture
+// the version in a group named version. Any {name} within the pattern is
+// replaced with the name of the repository. When the pattern is empty source
+// archives named <name>-<version> are matched.
+func NewHTTPIndex(uri, pattern string) (Client, error) {
+	return NewHTTPIndexClient(uri, pattern, http.DefaultClient)
+}
+
+func NewHTTPIndexClient(uri, pattern string, cl *http.Client) (Client, error) {
+	// Parse the url
+	indexURL, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse index link: %w", err)
+	}
+
+	if pattern == "" {
+		pattern = httpIndexPatternDefault
+	}
+
+	// Verify the pattern
+	if _, err = compileFilePattern(pattern, "name"); err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"index-url": indexURL.String(),
+		"pattern":   pattern,
+	}).Debug("connecting to http index")
+
+	return &httpIndexClient{client: cl, baseURL: indexURL, pattern: pattern, files: make(map[string][]Release)}, nil
+}
+
+// ListReleases falls back to the files in the index as there is no concept
+// of a release.
+func (c *httpIndexClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	return c.ListTags(ctx, owner, name, opt)
+}
+
+// ListTags lists the files in the index matching the pattern.
+//
+// The index is only requested when the first page is requested. Files are
+// ordered from the greatest version to the lowest and only the first file for
+// each version is returned, so archives in multiple formats are not repeated.
+func (c *httpIndexClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	indexURL := c.indexURL(owner)
+
+	logrus.WithFields(logrus.Fields{
+		"index": indexURL,
+		"name":  name,
+		"page":  opt.Page,
+	}).Debug("listing http index files")
+
+	key := indexURL + "#" + name
+
+	c.mu.Lock()
+	files, ok := c.files[key]
+	c.mu.Unlock()
+
+	if !ok || opt.Page <= startingPage {
+		matcher, err := compileFilePattern(c.pattern, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		links, err := c.listFiles(ctx, indexURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting files from index %s: %w", indexURL, err)
+		}
+
+		files = make([]Release, 0, len(links))
+		versions := make(map[string]bool)
+		versionIndex := matcher.SubexpIndex(httpIndexVersionGroup)
+
+		for _, link := range links {
+			fileName := path.Base(link.Path)
+
+			match := matcher.FindStringSubmatch(fileName)
+			if match == nil {
+				continue
+			}
+
+			version := match[versionIndex]
+			if version == "" || versions[version] {
+				continue
+			}
+			versions[version] = true
+
+			logrus.WithFields(logrus.Fields{
+				"file":    fileName,
+				"version": version,
+			}).Debug("found file")
+
+			files = append(files, Release{
+				Tag:         fileName,
+				SemVer:      generateVersion(version, versionMatcher),
+				VersionText: version,
+				URL:         link.String(),
+			})
+		}
+
+		sortReleases(files)
+
+		c.mu.Lock()
+		c.files[key] = files
+		c.mu.Unlock()
+	}
+
+	page, resp := paginate(files, opt)
+
+	return page, resp, nil
+}
+
+func (c *httpIndexClient) indexURL(owner string) string {
+	u := *c.baseURL
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	if owner != "" {
+		u.Path += "/" + strings.Trim(owner, "/")
+	}
+
+	// Index pages are directories so request them with a trailing slash to
+	// avoid a redirect
+	u.Path += "/"
+
+	return u.String()
+}
+
+// listFiles requests the index page and returns the files it links to.
+func (c *httpIndexClient) listFiles(ctx context.Context, indexURL string) ([]*url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, indexURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := doRequest(c.client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIndexLinks(req.URL, string(body)), nil
+}
+
+// parseIndexLinks extracts the files linked to from an HTML page.
+func parseIndexLinks(pageURL *url.URL, page string) []*url.URL {
+	var links []*url.URL
+
+	for _, match := range hrefMatcher.FindAllStringSubmatch(page, -1) {
+		link, err := pageURL.Parse(html.UnescapeString(match[1] + match[2] + match[3]))
+		if err != nil || link.Path == "" || strings.HasSuffix(link.Path, "/") {
+			continue
+		}
+
+		// Remove any query or fragment
+		link.RawQuery = ""
+		link.Fragment = ""
+
+		links = append(links, link)
+	}
+
+	return links
+}
+
+// compileFilePattern compiles the pattern for the repository name.
+func compileFilePattern(pattern, name string) (*regexp.Regexp, error) {
+	matcher, err := regexp.Compile(strings.ReplaceAll(pattern, httpIndexNamePlaceholder, regexp.QuoteMeta(name)))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse file pattern %s: %w", pattern, err)
+	}
+
+	if matcher.SubexpIndex(httpIndexVersionGroup) < 0 {
+		return nil, fmt.Errorf("file pattern %s has no %s group: %w", pattern, httpIndexVersionGroup, ErrScmDriver)
+	}
+
+	return matcher, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newHTTPIndexServer serves the saved index pages within testdata.
+func newHTTPIndexServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/source/old/1.1.1/", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/httpindex/apache.html")
+	})
+	mux.HandleFunc("/pub/gnu/libiconv/", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/httpindex/nginx.html")
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHTTPIndexListTags(t *testing.T) {
+	server := newHTTPIndexServer(t)
+
+	client, err := NewHTTPIndexClient(server.URL+"/pub/gnu", "", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, resp, err := client.ListTags(context.Background(), "libiconv", "libiconv", ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var found []string
+	for _, file := range files {
+		found = append(found, file.Tag+"="+file.VersionText)
+	}
+
+	// Signatures, documentation and other formats of a version are skipped
+	expected := "[libiconv-1.17.tar.gz=1.17 libiconv-1.16.tar.gz=1.16 libiconv-1.9.2.tar.gz=1.9.2]"
+	if fmt.Sprint(found) != expected {
+		t.Errorf("unexpected files %v", found)
+	}
+	if files[0].URL != server.URL+"/pub/gnu/libiconv/libiconv-1.17.tar.gz" {
+		t.Errorf("unexpected url %s", files[0].URL)
+	}
+	if resp.NextPage != 0 {
+		t.Errorf("expected no next page, got %d", resp.NextPage)
+	}
+}
+
+func TestHTTPIndexScheme(t *testing.T) {
+	server := newHTTPIndexServer(t)
+
+	client, err := NewHTTPIndexClient(server.URL+"/source/old", "", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scheme, err := SchemeFromName(SchemeOpenSSL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release, ok, err := GreatestVersion(Releases(client, ListReleaseOptions{
+		Owner:  "1.1.1",
+		Repo:   "openssl",
+		Tags:   true,
+		Scheme: scheme,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok || release.Tag != "openssl-1.1.1w.tar.gz" || release.Version == nil || release.Version.String() != "1.1.1w" {
+		t.Errorf("unexpected greatest release %s %v", release.Tag, release.Version)
+	}
+}
+
+func TestHTTPIndexPattern(t *testing.T) {
+	server := newHTTPIndexServer(t)
+
+	client, err := NewHTTPIndexClient(server.URL+"/pub/gnu", `^{name}-documentation-(?P<version>[\d.]+)\.tar\.gz$`, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, _, err := client.ListTags(context.Background(), "libiconv", "libiconv", ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 || files[0].VersionText != "1.17" {
+		t.Errorf("unexpected files %v", files)
+	}
+}
+
+func TestHTTPIndexPatternWithoutVersion(t *testing.T) {
+	if _, err := NewHTTPIndex("https://example.com", `^{name}-[\d.]+\.tar\.gz$`); err == nil {
+		t.Error("expected an error for a pattern without a version group")
+	}
+}
+
+func TestHTTPIndexFromDriver(t *testing.T) {
+	server := newHTTPIndexServer(t)
+
+	// The pattern is passed through the options of the driver
+	client, err := NewClientFromDriverWithOptions(DriverHTTPIndex, server.URL+"/pub/gnu", "", ClientOptions{
+		Pattern: `^{name}-documentation-(?P<version>[\d.]+)\.tar\.gz$`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, _, err := client.ListTags(context.Background(), "libiconv", "libiconv", ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected the pattern to be applied, got %d files", len(files))
+	}
+
+	// The default options use the default pattern
+	client, err = NewClientFromDriver(DriverHTTPIndex, server.URL+"/pub/gnu", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if files, _, err = client.ListTags(context.Background(), "libiconv", "libiconv", ListOptions{}); err != nil || len(files) != 3 {
+		t.Errorf("expected 3 files, got %d %v", len(files), err)
+	}
+}
@@ -4,26 +4,88 @@
 
 package reqcheck
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"iter"
+)
 
-func ReduceGreatestVersion(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
-	if acc == nil {
-		return elem, nil
+// Reduce combines the releases into a single value, stopping at the first
+// error.
+func Reduce[T any](seq iter.Seq2[Release, error], init T, f func(acc T, release Release) T) (T, error) {
+	acc := init
+
+	for release, err := range seq {
+		if err != nil {
+			return acc, err
+		}
+
+		acc = f(acc, release)
+	}
+
+	return acc, nil
+}
+
+// GreatestVersion returns the release with the greatest version. Releases
+// without a version are skipped. False is returned when no release has a
+// version.
+func GreatestVersion(seq iter.Seq2[Release, error]) (Release, bool, error) {
+	greatest, err := Reduce(seq, (*Release)(nil), func(acc *Release, release Release) *Release {
+		if release.Version == nil {
+			return acc
+		}
+		if acc == nil {
+			return &release
+		}
+
+		greater := greaterVersion(*acc, release)
+
+		return &greater
+	})
+	if err != nil || greatest == nil {
+		return Release{}, false, err
 	}
 
-	accRelease := acc.(Release)
-	if accRelease.SemVer == nil {
-		return elem, nil
+	return *greatest, true, nil
+}
+
+// greaterVersion returns the release with the greater version. A release
+// without a version, or with a version that is not comparable, loses to the
+// accumulated release.
+func greaterVersion(acc, elem Release) Release {
+	if acc.Version == nil {
+		return elem
 	}
 
-	elemRelease := elem.(Release)
-	if elemRelease.SemVer == nil {
-		return acc, nil
+	if elem.Version == nil || !Comparable(acc.Version, elem.Version) {
+		return acc
+	}
+
+	if acc.Version.Compare(elem.Version) > 0 {
+		return acc
+	}
+
+	return elem
+}
+
+func ReduceGreatestVersion(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+	elemRelease, ok := elem.(Release)
+	if !ok {
+		return nil, fmt.Errorf("unexpected item %T: %w", elem, ErrScmDriver)
+	}
+
+	if acc == nil {
+		if elemRelease.Version == nil {
+			return nil, nil
+		}
+
+		return elemRelease, nil
 	}
 
-	if accRelease.SemVer.GreaterThan(elemRelease.SemVer) {
-		return acc, nil
+	accRelease, ok := acc.(Release)
+	if !ok {
+		return nil, fmt.Errorf("unexpected item %T: %w", acc, ErrScmDriver)
 	}
 
-	return elem, nil
+	return greaterVersion(accRelease, elemRelease), nil
 }
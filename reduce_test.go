@@ -0,0 +1,68 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGreatestVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		releases []Release
+		expected string
+	}{
+		{"numeric order", []Release{dottedRelease(t, "v9.0"), dottedRelease(t, "v10.0"), dottedRelease(t, "v9.5")}, "v10.0"},
+		{"first without version", []Release{{Tag: "nightly"}, dottedRelease(t, "v1.0"), dottedRelease(t, "v1.2")}, "v1.2"},
+		{"last without version", []Release{dottedRelease(t, "v1.0"), {Tag: "nightly"}}, "v1.0"},
+		{"equal versions", []Release{dottedRelease(t, "v1.2"), dottedRelease(t, "v1.2.0")}, "v1.2.0"},
+		{"other scheme", []Release{dottedRelease(t, "v1.0"), {Tag: "v2.0.0", Version: SemVerVersion(generateVersion("v2.0.0", versionMatcher))}}, "v1.0"},
+	}
+
+	for _, test := range tests {
+		latest, ok, err := GreatestVersion(testReleases(test.releases, nil))
+		if err != nil || !ok {
+			t.Errorf("%s: expected a release, got %v", test.name, err)
+			continue
+		}
+
+		if latest.Tag != test.expected || latest.Version == nil {
+			t.Errorf("%s: expected %s, got %+v", test.name, test.expected, latest)
+		}
+	}
+}
+
+func TestGreatestVersionNone(t *testing.T) {
+	// Releases without a version are never chosen
+	_, ok, err := GreatestVersion(testReleases([]Release{{Tag: "nightly"}, {Tag: "CVE-2021-3520"}}, nil))
+	if ok || err != nil {
+		t.Errorf("expected no release, got %v %v", ok, err)
+	}
+
+	failed := errors.New("failed")
+	if _, _, err = GreatestVersion(testReleases([]Release{dottedRelease(t, "v1.0")}, failed)); !errors.Is(err, failed) {
+		t.Errorf("expected the error, got %v", err)
+	}
+}
+
+func TestReduceGreatestVersion(t *testing.T) {
+	var acc interface{}
+	for _, release := range []Release{{Tag: "nightly"}, dottedRelease(t, "v1.0"), {Tag: "CVE-2021-3520"}, dottedRelease(t, "v1.1")} {
+		var err error
+		if acc, err = ReduceGreatestVersion(context.Background(), acc, release); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if release, ok := acc.(Release); !ok || release.Tag != "v1.1" {
+		t.Errorf("unexpected release %v", acc)
+	}
+
+	if _, err := ReduceGreatestVersion(context.Background(), nil, "v1.0"); err == nil {
+		t.Error("expected an error for an item that is not a release")
+	}
+}
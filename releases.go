@@ -7,16 +7,33 @@ package reqcheck
 import (
 	"context"
 	"fmt"
+	"iter"
+	"sort"
 
 	"github.com/reactivex/rxgo/v2"
 	"github.com/sirupsen/logrus"
 )
 
 type ListReleaseOptions struct {
-	Owner   string
-	Repo    string
-	Tags    bool
+	Owner string
+	Repo  string
+	Tags  bool
+	// LimitTo is the number of releases to return after any exclusions.
 	LimitTo int
+	// ExcludeDrafts removes releases marked as a draft upstream.
+	ExcludeDrafts bool
+	// ExcludePrereleases removes releases marked as a pre-release upstream.
+	ExcludePrereleases bool
+	// Version overrides how the version is extracted from the tag.
+	Version *VersionPattern
+	// Scheme reads the version of each release. Defaults to semver.
+	Scheme Scheme
+	// StopBelow stops paging once every version on a page is lower than it.
+	// The releases on that page are still emitted.
+	StopBelow Version
+	// Context stops paging when it is done. Defaults to the background
+	// context.
+	Context context.Context
 }
 
 const (
@@ -26,9 +43,34 @@ const (
 )
 
 func ListReleases(client Client, opts ListReleaseOptions) rxgo.Observable {
-	listOpts := ListOptions{Page: startingPage, PerPage: perPageDefault}
+	var rxOpts []rxgo.Option
+	if opts.Context != nil {
+		rxOpts = append(rxOpts, rxgo.WithContext(opts.Context))
+	}
+
+	return rxgo.Create([]rxgo.Producer{
+		func(ctx context.Context, next chan<- rxgo.Item) {
+			opts.Context = ctx
+
+			for release, err := range Releases(client, opts) {
+				if err != nil {
+					rxgo.Error(err).SendContext(ctx, next)
+
+					return
+				}
 
-	var listFunc func(context.Context, string, string, ListOptions) ([]Release, error)
+				if !rxgo.Of(release).SendContext(ctx, next) {
+					return
+				}
+			}
+		},
+	}, rxOpts...)
+}
+
+// Releases iterates over the releases of a repository. Paging stops as soon
+// as the loop is exited, the context is done or an error is returned.
+func Releases(client Client, opts ListReleaseOptions) iter.Seq2[Release, error] {
+	var listFunc func(context.Context, string, string, ListOptions) ([]Release, *Response, error)
 	if opts.Tags {
 		listFunc = client.ListTags
 	} else {
@@ -40,35 +82,164 @@ func ListReleases(client Client, opts ListReleaseOptions) rxgo.Observable {
 		opts.LimitTo = limitToDefault
 	}
 
-	itemCount := 0
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
-	return rxgo.Create([]rxgo.Producer{
-		func(ctx context.Context, next chan<- rxgo.Item) {
-			for {
-				items, err := listFunc(ctx, opts.Owner, opts.Repo, listOpts)
-				if err != nil {
-					next <- rxgo.Error(fmt.Errorf("could not access %s/%s releases: %w", opts.Owner, opts.Repo, err))
+	return func(yield func(Release, error) bool) {
+		listOpts := ListOptions{Page: startingPage, PerPage: perPageDefault}
+		itemCount := 0
 
-					break
-				}
+		for {
+			// Stop when the caller is no longer interested
+			if ctx.Err() != nil {
+				logrus.WithError(ctx.Err()).Debug("stopped listing releases")
+
+				return
+			}
+
+			items, resp, err := listFunc(ctx, opts.Owner, opts.Repo, listOpts)
+			if err != nil {
+				yield(Release{}, fmt.Errorf("could not access %s/%s releases: %w", opts.Owner, opts.Repo, err))
+
+				return
+			}
+
+			// Count the versions on the page at or above the cutoff
+			versions, newer := 0, 0
 
-				for _, item := range items {
-					next <- rxgo.Of(item)
+			for _, item := range items {
+				if opts.Version != nil {
+					item.SemVer = opts.Version.Version(item.Tag)
+				}
 
-					itemCount++
-					if itemCount >= opts.LimitTo {
-						logrus.WithField("limit-to", opts.LimitTo).Debug("reached query limit")
+				item.Version = parseReleaseVersion(item, opts.Scheme, opts.Version)
 
-						break
+				if opts.StopBelow != nil && item.Version != nil && Comparable(item.Version, opts.StopBelow) {
+					versions++
+					if item.Version.Compare(opts.StopBelow) >= 0 {
+						newer++
 					}
 				}
 
-				if len(items) < listOpts.PerPage {
-					break
+				// Excluded releases do not count towards the limit
+				if (opts.ExcludeDrafts && !IsPublishedRelease(item)) || (opts.ExcludePrereleases && !IsUpstreamStableRelease(item)) {
+					continue
+				}
+
+				if !yield(item, nil) {
+					return
 				}
 
-				listOpts.Page++
+				itemCount++
+				if itemCount >= opts.LimitTo {
+					logrus.WithField("limit-to", opts.LimitTo).Debug("reached query limit")
+
+					return
+				}
 			}
-		},
+
+			if versions > 0 && newer == 0 {
+				logrus.WithField("stop-below", opts.StopBelow.String()).Debug("reached versions below cutoff")
+
+				return
+			}
+
+			if resp == nil {
+				return
+			} else if resp.NextCursor != "" {
+				listOpts.Cursor = resp.NextCursor
+			} else if resp.NextPage != 0 {
+				listOpts.Page = resp.NextPage
+			} else {
+				return
+			}
+		}
+	}
+}
+
+// parseReleaseVersion reads the version of the release using the scheme and
+// the pattern when present. Without a pattern the version text of the release
+// is read in preference to the tag.
+func parseReleaseVersion(release Release, scheme Scheme, pattern *VersionPattern) Version {
+	if scheme == nil || scheme.Name() == SchemeSemVer {
+		if release.SemVer == nil {
+			return nil
+		}
+
+		return SemVerVersion(release.SemVer)
+	}
+
+	if pattern != nil {
+		return pattern.Parse(scheme, release.Tag)
+	}
+
+	text := release.Tag
+	if release.VersionText != "" {
+		text = release.VersionText
+	}
+
+	version, err := scheme.Parse(text)
+	if err != nil {
+		return nil
+	}
+
+	return version
+}
+
+// ReleaseVersionText returns the version of the release as written upstream.
+// This is the version text of the release, or the tag with the prefix and
+// suffix of the pattern removed, without a leading v or project name.
+func ReleaseVersionText(release Release, pattern *VersionPattern) string {
+	text := release.Tag
+	if release.VersionText != "" {
+		text = release.VersionText
+	} else if pattern != nil {
+		if extracted, ok := pattern.extract(release.Tag); ok {
+			text = extracted
+		}
+	}
+
+	return versionPrefixMatcher.ReplaceAllString(text, "")
+}
+
+// sortReleases orders releases from the greatest version to the lowest with
+// releases that are not versions last.
+func sortReleases(releases []Release) {
+	sort.SliceStable(releases, func(i, j int) bool {
+		if releases[j].SemVer == nil {
+			return releases[i].SemVer != nil
+		}
+		if releases[i].SemVer == nil {
+			return false
+		}
+
+		return releases[i].SemVer.GreaterThan(releases[j].SemVer)
 	})
 }
+
+// paginate returns the page of results described by opt.
+func paginate(releases []Release, opt ListOptions) ([]Release, *Response) {
+	perPage := opt.PerPage
+	if perPage <= 0 {
+		perPage = perPageDefault
+	}
+
+	page := opt.Page
+	if page <= 0 {
+		page = startingPage
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(releases) {
+		return []Release{}, &Response{}
+	}
+
+	end := min(start+perPage, len(releases))
+	if end == len(releases) {
+		return releases[start:end], &Response{}
+	}
+
+	return releases[start:end], &Response{NextPage: page + 1}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// fakeClient serves releases v1.<n-1>.0 down to v1.0.0 in pages, counting the
+// pages requested.
+type fakeClient struct {
+	count   int
+	cursors bool
+	nilLast bool
+	mark    func(i int, release *Release)
+
+	releasePages int
+	tagPages     int
+}
+
+func (c *fakeClient) ListReleases(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	c.releasePages++
+
+	return c.page(opt)
+}
+
+func (c *fakeClient) ListTags(ctx context.Context, owner, name string, opt ListOptions) ([]Release, *Response, error) {
+	c.tagPages++
+
+	return c.page(opt)
+}
+
+func (c *fakeClient) page(opt ListOptions) ([]Release, *Response, error) {
+	start := (opt.Page - 1) * opt.PerPage
+	if c.cursors && opt.Cursor != "" {
+		start, _ = strconv.Atoi(opt.Cursor)
+	}
+
+	var releases []Release
+	for i := start; i < start+opt.PerPage && i < c.count; i++ {
+		tag := fmt.Sprintf("v1.%d.0", c.count-1-i)
+		release := Release{Tag: tag, SemVer: generateVersion(tag, versionMatcher)}
+		if c.mark != nil {
+			c.mark(i, &release)
+		}
+
+		releases = append(releases, release)
+	}
+
+	end := start + opt.PerPage
+	switch {
+	case end >= c.count && c.nilLast:
+		return releases, nil, nil
+	case end >= c.count:
+		return releases, &Response{}, nil
+	case c.cursors:
+		return releases, &Response{NextCursor: strconv.Itoa(end)}, nil
+	default:
+		return releases, &Response{NextPage: opt.Page + 1}, nil
+	}
+}
+
+func collectTags(t *testing.T, client Client, opts ListReleaseOptions) []string {
+	t.Helper()
+
+	var tags []string
+	for release, err := range Releases(client, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tags = append(tags, release.Tag)
+	}
+
+	return tags
+}
+
+func TestReleasesAllPages(t *testing.T) {
+	client := &fakeClient{count: 100}
+
+	tags := collectTags(t, client, ListReleaseOptions{})
+
+	if len(tags) != 100 || tags[0] != "v1.99.0" || tags[99] != "v1.0.0" {
+		t.Errorf("unexpected releases %v", tags)
+	}
+	if client.releasePages != 4 || client.tagPages != 0 {
+		t.Errorf("expected 4 release pages, got %d releases and %d tags", client.releasePages, client.tagPages)
+	}
+}
+
+func TestReleasesLimitTo(t *testing.T) {
+	tests := []struct {
+		limitTo int
+		pages   int
+	}{
+		{1, 1},
+		{30, 1},
+		{31, 2},
+		{60, 2},
+	}
+
+	for _, test := range tests {
+		client := &fakeClient{count: 1000}
+
+		tags := collectTags(t, client, ListReleaseOptions{Tags: true, LimitTo: test.limitTo})
+
+		if len(tags) != test.limitTo {
+			t.Errorf("limit %d: expected %d releases, got %d", test.limitTo, test.limitTo, len(tags))
+		}
+		if client.tagPages != test.pages {
+			t.Errorf("limit %d: expected %d pages, got %d", test.limitTo, test.pages, client.tagPages)
+		}
+	}
+}
+
+func TestReleasesLimitToAfterExclusions(t *testing.T) {
+	// The newest releases are drafts and pre-releases
+	client := &fakeClient{count: 100, mark: func(i int, release *Release) {
+		release.Draft = i < 20
+		release.Prerelease = i >= 20 && i < 40
+	}}
+
+	tags := collectTags(t, client, ListReleaseOptions{LimitTo: 5, ExcludeDrafts: true, ExcludePrereleases: true})
+
+	if fmt.Sprint(tags) != "[v1.59.0 v1.58.0 v1.57.0 v1.56.0 v1.55.0]" {
+		t.Errorf("unexpected releases %v", tags)
+	}
+	if client.releasePages != 2 {
+		t.Errorf("expected 2 pages, got %d", client.releasePages)
+	}
+}
+
+func TestReleasesStopBelow(t *testing.T) {
+	stopBelow, err := semverScheme{}.Parse("1.50.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &fakeClient{count: 100}
+
+	tags := collectTags(t, client, ListReleaseOptions{StopBelow: stopBelow})
+
+	// Paging stops after the first page entirely below the cutoff
+	if len(tags) != 90 || tags[89] != "v1.10.0" {
+		t.Errorf("unexpected releases %d ending %s", len(tags), tags[len(tags)-1])
+	}
+	if client.releasePages != 3 {
+		t.Errorf("expected 3 pages, got %d", client.releasePages)
+	}
+}
+
+func TestReleasesCursor(t *testing.T) {
+	client := &fakeClient{count: 75, cursors: true}
+
+	tags := collectTags(t, client, ListReleaseOptions{})
+
+	if len(tags) != 75 || tags[30] != "v1.44.0" || tags[74] != "v1.0.0" {
+		t.Errorf("unexpected releases %v", tags)
+	}
+	if client.releasePages != 3 {
+		t.Errorf("expected 3 pages, got %d", client.releasePages)
+	}
+}
+
+func TestReleasesNilResponse(t *testing.T) {
+	client := &fakeClient{count: 45, nilLast: true}
+
+	tags := collectTags(t, client, ListReleaseOptions{})
+
+	if len(tags) != 45 || client.releasePages != 2 {
+		t.Errorf("expected 45 releases from 2 pages, got %d from %d", len(tags), client.releasePages)
+	}
+}
+
+func TestReleasesBreak(t *testing.T) {
+	client := &fakeClient{count: 1000}
+
+	for release, err := range Releases(client, ListReleaseOptions{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if release.Tag == "v1.995.0" {
+			break
+		}
+	}
+
+	if client.releasePages != 1 {
+		t.Errorf("expected 1 page, got %d", client.releasePages)
+	}
+}
+
+func TestReleasesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &fakeClient{count: 1000}
+
+	count := 0
+	for _, err := range Releases(client, ListReleaseOptions{Context: ctx}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Cancel part way through the first page
+		count++
+		if count == 10 {
+			cancel()
+		}
+	}
+
+	if count != 30 || client.releasePages != 1 {
+		t.Errorf("expected the first page of 30 releases, got %d from %d pages", count, client.releasePages)
+	}
+}
+
+func TestListReleasesObservable(t *testing.T) {
+	client := &fakeClient{count: 1000}
+
+	var tags []string
+	for item := range ListReleases(client, ListReleaseOptions{LimitTo: 40}).Observe() {
+		if item.E != nil {
+			t.Fatal(item.E)
+		}
+
+		tags = append(tags, item.V.(Release).Tag)
+	}
+
+	if len(tags) != 40 || client.releasePages != 2 {
+		t.Errorf("expected 40 releases from 2 pages, got %d from %d", len(tags), client.releasePages)
+	}
+}
+
+func TestReleaseVersionText(t *testing.T) {
+	pattern, err := NewVersionPattern("", "release-", "-final", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		release  Release
+		pattern  *VersionPattern
+		expected string
+	}{
+		{Release{Tag: "v1.3"}, nil, "1.3"},
+		{Release{Tag: "curl-8_10_0"}, nil, "8_10_0"},
+		{Release{Tag: "release-2.0-final"}, pattern, "2.0"},
+		{Release{Tag: "libiconv-1.17.tar.gz", VersionText: "1.17"}, pattern, "1.17"},
+	}
+
+	for _, test := range tests {
+		if text := ReleaseVersionText(test.release, test.pattern); text != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.release.Tag, test.expected, text)
+		}
+	}
+}
+
+func TestReleasesGreatestDottedVersion(t *testing.T) {
+	// The newest tag is a pre-release that is not a dotted version
+	client := &fakeClient{count: 12, mark: func(i int, release *Release) {
+		if i == 0 {
+			release.Tag = "v20.0.0-rc1"
+			release.SemVer = generateVersion(release.Tag, versionMatcher)
+		}
+	}}
+
+	dotted, err := SchemeFromName(SchemeDotted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	constraint, err := NewConstraint(dotted, ">= 1.9")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	releases := Filter(Releases(client, ListReleaseOptions{Scheme: dotted}), And(HasVersion, SatisfiesConstraint(constraint)))
+
+	// 1.10.0 is greater than 1.9.0 and the pre-release is never chosen
+	latest, ok, err := GreatestVersion(releases)
+	if err != nil || !ok {
+		t.Fatalf("expected a release, got %v", err)
+	}
+	if latest.Tag != "v1.10.0" || latest.Version == nil || latest.Version.String() != "1.10.0" {
+		t.Errorf("unexpected release %+v", latest)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RetryOptions configures how failed requests are retried.
+type RetryOptions struct {
+	// MaxRetries is the number of times a request is retried. Zero disables
+	// retries.
+	MaxRetries int
+	// MaxWait is the longest time spent waiting between the attempts of a
+	// single request. A rate limit that resets later than this is not waited
+	// on.
+	MaxWait time.Duration
+	// MinBackoff is the wait before retrying a server or network error. It is
+	// doubled for each attempt.
+	MinBackoff time.Duration
+	// MaxBackoff is the longest wait before retrying a server or network error.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryOptions returns the options used when none are configured.
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxRetries: 3,
+		MaxWait:    2 * time.Minute,
+		MinBackoff: time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// Rate limit headers used by GitHub and GitLab respectively
+var (
+	rateLimitLimitHeaders     = []string{"X-RateLimit-Limit", "RateLimit-Limit"}
+	rateLimitRemainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+	rateLimitResetHeaders     = []string{"X-RateLimit-Reset", "RateLimit-Reset"}
+)
+
+type retryTransport struct {
+	base http.RoundTripper
+	opts RetryOptions
+}
+
+// NewRetryTransport wraps the transport so requests are retried.
+//
+// Responses that hit a rate limit are retried once the limit resets, as
+// reported by the Retry-After or rate limit reset headers, provided this is
+// within the maximum wait. Server errors and network errors are retried with
+// an exponential backoff. The remaining quota is logged for each response.
+//
+// Only idempotent requests are retried, so a POST is never sent twice unless
+// it has an idempotency key or its context is marked by WithRetryable.
+func NewRetryTransport(base http.RoundTripper, opts RetryOptions) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &retryTransport{base: base, opts: opts}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var waited time.Duration
+
+	backoff := t.opts.MinBackoff
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, errors.New("request body cannot be resent")
+			}
+
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err == nil {
+			logRateLimit(req, resp)
+		}
+
+		if attempt >= t.opts.MaxRetries || !isIdempotent(req) {
+			return resp, err
+		}
+
+		wait, retry := t.retryAfter(resp, err, backoff)
+		if !retry {
+			return resp, err
+		}
+
+		if waited+wait > t.opts.MaxWait {
+			logrus.WithFields(logrus.Fields{
+				"url":  req.URL.Redacted(),
+				"wait": wait,
+			}).Warn("not retrying request as the wait is too long")
+
+			return resp, err
+		}
+
+		fields := logrus.Fields{
+			"url":     req.URL.Redacted(),
+			"attempt": attempt + 1,
+			"wait":    wait,
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.Status
+		}
+		logrus.WithFields(fields).Info("retrying request")
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		if err = sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		waited += wait
+		if wait == backoff {
+			backoff = min(backoff*2, t.opts.MaxBackoff)
+		}
+	}
+}
+
+// retryAfter determines whether the request should be retried and how long to
+// wait before doing so.
+func (t *retryTransport) retryAfter(resp *http.Response, err error, backoff time.Duration) (time.Duration, bool) {
+	if err != nil {
+		// Cancelled requests should not be retried
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+
+		return backoff, true
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if wait, ok := rateLimitWait(resp); ok {
+			return wait, true
+		}
+
+		return backoff, true
+	case resp.StatusCode == http.StatusForbidden:
+		// GitHub signals rate limits with a forbidden status
+		if resp.Header.Get("Retry-After") == "" && firstHeader(resp.Header, rateLimitRemainingHeaders) != "0" {
+			return 0, false
+		}
+
+		return rateLimitWait(resp)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return backoff, true
+	}
+
+	return 0, false
+}
+
+type retryableKey struct{}
+
+// WithRetryable marks requests made with the context as safe to send again,
+// such as a query that only reads but is sent with a POST.
+func WithRetryable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryableKey{}, true)
+}
+
+// isIdempotent determines if the request can be sent again without side
+// effects.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	if retryable, _ := req.Context().Value(retryableKey{}).(bool); retryable {
+		return true
+	}
+
+	// Follow net/http in treating requests with an idempotency key as safe
+	return req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != ""
+}
+
+// rateLimitWait reads how long to wait for a rate limit to reset.
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return max(time.Until(date), 0), true
+		}
+	}
+
+	if reset := firstHeader(resp.Header, rateLimitResetHeaders); reset != "" {
+		if epoch, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			return max(time.Until(time.Unix(epoch, 0)), 0), true
+		}
+	}
+
+	return 0, false
+}
+
+func logRateLimit(req *http.Request, resp *http.Response) {
+	remaining := firstHeader(resp.Header, rateLimitRemainingHeaders)
+	if remaining == "" {
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"host":      req.URL.Host,
+		"limit":     firstHeader(resp.Header, rateLimitLimitHeaders),
+		"remaining": remaining,
+		"reset":     firstHeader(resp.Header, rateLimitResetHeaders),
+	}).Debug("rate limit")
+}
+
+func firstHeader(header http.Header, keys []string) string {
+	for _, key := range keys {
+		if value := header.Get(key); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxRetries: 3,
+		MaxWait:    time.Second,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	}
+}
+
+// newFlakyServer fails the first requests with the handler then succeeds.
+func newFlakyServer(t *testing.T, failures int32, fail http.HandlerFunc) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var count atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) <= failures {
+			fail(w, r)
+			return
+		}
+
+		fmt.Fprint(w, "[]")
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &count
+}
+
+func getWithRetries(t *testing.T, url string, opts RetryOptions) *http.Response {
+	t.Helper()
+
+	client := &http.Client{Transport: NewRetryTransport(nil, opts)}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	return resp
+}
+
+func TestRetryRateLimit(t *testing.T) {
+	tests := map[string]http.HandlerFunc{
+		"retry after": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+		"github reset": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+		},
+		"gitlab reset": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("RateLimit-Remaining", "0")
+			w.Header().Set("RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+		"server error": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	for name, fail := range tests {
+		server, count := newFlakyServer(t, 2, fail)
+
+		resp := getWithRetries(t, server.URL, testRetryOptions())
+		if resp.StatusCode != http.StatusOK || count.Load() != 3 {
+			t.Errorf("%s: expected success on the third attempt, got %s after %d", name, resp.Status, count.Load())
+		}
+	}
+}
+
+func TestRetryForbidden(t *testing.T) {
+	server, count := newFlakyServer(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "10")
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	resp := getWithRetries(t, server.URL, testRetryOptions())
+	if resp.StatusCode != http.StatusForbidden || count.Load() != 1 {
+		t.Errorf("expected no retries, got %s after %d", resp.Status, count.Load())
+	}
+}
+
+func TestRetryMaxWait(t *testing.T) {
+	server, count := newFlakyServer(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	resp := getWithRetries(t, server.URL, testRetryOptions())
+	if resp.StatusCode != http.StatusTooManyRequests || count.Load() != 1 {
+		t.Errorf("expected the rate limit to be returned, got %s after %d", resp.Status, count.Load())
+	}
+}
+
+func TestRetryMaxRetries(t *testing.T) {
+	server, count := newFlakyServer(t, 10, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	resp := getWithRetries(t, server.URL, testRetryOptions())
+	if resp.StatusCode != http.StatusInternalServerError || count.Load() != 4 {
+		t.Errorf("expected four attempts, got %s after %d", resp.Status, count.Load())
+	}
+}
+
+func TestRetryGitHubClient(t *testing.T) {
+	server, count := newFlakyServer(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	client, err := NewGitHubClient(server.URL, "token", &http.Client{Transport: NewRetryTransport(nil, testRetryOptions())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = client.ListTags(context.Background(), "owner", "repo", ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if count.Load() != 2 {
+		t.Errorf("expected two attempts, got %d", count.Load())
+	}
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	tests := []struct {
+		method    string
+		header    string
+		retryable bool
+		attempts  int32
+	}{
+		{http.MethodPost, "", false, 1},
+		{http.MethodPatch, "", false, 1},
+		{http.MethodPost, "Idempotency-Key", false, 2},
+		{http.MethodPost, "", true, 2},
+		{http.MethodPut, "", false, 2},
+	}
+
+	for _, test := range tests {
+		server, count := newFlakyServer(t, 1, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		ctx := context.Background()
+		if test.retryable {
+			ctx = WithRetryable(ctx)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, test.method, server.URL, strings.NewReader("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.header != "" {
+			req.Header.Set(test.header, "key")
+		}
+
+		client := &http.Client{Transport: NewRetryTransport(nil, testRetryOptions())}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if count.Load() != test.attempts {
+			t.Errorf("%s %s %v: expected %d attempts, got %d", test.method, test.header, test.retryable, test.attempts, count.Load())
+		}
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+type (
+	// Version is a version read by a Scheme.
+	Version interface {
+		// String returns the version in its canonical form.
+		String() string
+		// Compare returns a negative number when the version is lower than other,
+		// zero when they are equal and a positive number when it is greater.
+		// Versions of different schemes are not Comparable and compare as
+		// equal.
+		Compare(other Version) int
+		// Prerelease returns the pre-release of the version, which is empty for
+		// a stable version.
+		Prerelease() string
+	}
+
+	// Scheme reads versions in a particular format.
+	Scheme interface {
+		// Name of the scheme within configuration.
+		Name() string
+		// Parse reads a version. A leading v or the name of the project
+		// followed by a separator, such as curl- or OpenSSL_, is ignored.
+		Parse(s string) (Version, error)
+	}
+)
+
+var ErrVersionScheme = errors.New("version scheme error")
+
+const (
+	// SchemeSemVer is a semantic version such as 1.2.3-rc.1.
+	SchemeSemVer = "semver"
+	// SchemeCalVer is a calendar version starting with the year such as
+	// 2024.01 or 2024.01.15.
+	SchemeCalVer = "calver"
+	// SchemeDate is a date such as 20240115 or 2024-01-15 optionally followed
+	// by further numbers.
+	SchemeDate = "date"
+	// SchemeDotted is any number of numeric components such as 1.2.3.4.
+	SchemeDotted = "dotted"
+	// SchemeOpenSSL is a dotted version followed by a letter release such as
+	// 1.1.1w.
+	SchemeOpenSSL = "openssl"
+)
+
+var (
+	schemes = map[string]Scheme{
+		SchemeSemVer:  semverScheme{},
+		SchemeCalVer:  numericScheme{name: SchemeCalVer, matcher: calverMatcher},
+		SchemeDate:    numericScheme{name: SchemeDate, matcher: dateMatcher},
+		SchemeDotted:  numericScheme{name: SchemeDotted, matcher: dottedMatcher},
+		SchemeOpenSSL: numericScheme{name: SchemeOpenSSL, matcher: opensslMatcher},
+	}
+
+	versionPrefixMatcher = regexp.MustCompile(`^(?:[a-zA-Z]+(?:[-_][a-zA-Z]+)*[-_])?[vV]?`)
+	junkPrefixMatcher    = regexp.MustCompile(`(?i)^(?:cve|snapshot|nightly|build)[-_]`)
+	wildcardMatcher      = regexp.MustCompile(`^(.*?)(?:^|[._-])[xX*](?:[._-][xX*])*$`)
+	calverMatcher        = regexp.MustCompile(`^(?:\d{4}|\d{2})[._-]\d{1,2}(?:[._-]\d+)*$`)
+	dateMatcher          = regexp.MustCompile(`^(?:\d{8}|\d{4}[._-]\d{2}[._-]\d{2})(?:[._-]\d+)*$`)
+	dottedMatcher        = regexp.MustCompile(`^\d+(?:[._-]\d+)*$`)
+	opensslMatcher       = regexp.MustCompile(`^\d+(?:[._-]\d+)*[a-z]*$`)
+	numericSeparators    = regexp.MustCompile(`[._-]`)
+)
+
+// SchemeFromName returns the scheme with the given name. An empty name is
+// the semver scheme.
+func SchemeFromName(name string) (Scheme, error) {
+	if name == "" {
+		name = SchemeSemVer
+	}
+
+	scheme, ok := schemes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown version scheme %s: %w", name, ErrVersionScheme)
+	}
+
+	return scheme, nil
+}
+
+// Comparable reports whether the versions are of the same scheme, so their
+// order is meaningful.
+func Comparable(a, b Version) bool {
+	switch a := a.(type) {
+	case semverVersion:
+		_, ok := b.(semverVersion)
+
+		return ok
+	case numericVersion:
+		o, ok := b.(numericVersion)
+
+		return ok && o.scheme == a.scheme
+	default:
+		return false
+	}
+}
+
+// SemVerVersion wraps a semantic version as a Version.
+func SemVerVersion(v *semver.Version) Version {
+	return semverVersion{v}
+}
+
+type (
+	semverScheme struct{}
+
+	semverVersion struct {
+		*semver.Version
+	}
+)
+
+func (semverScheme) Name() string {
+	return SchemeSemVer
+}
+
+func (semverScheme) Parse(s string) (Version, error) {
+	v := generateVersion(s, versionMatcher)
+	if v == nil {
+		return nil, fmt.Errorf("%s is not a semantic version: %w", s, ErrVersionScheme)
+	}
+
+	return semverVersion{v}, nil
+}
+
+func (v semverVersion) Compare(other Version) int {
+	o, ok := other.(semverVersion)
+	if !ok {
+		return 0
+	}
+
+	return v.Version.Compare(o.Version)
+}
+
+type (
+	numericScheme struct {
+		name    string
+		matcher *regexp.Regexp
+	}
+
+	// numericVersion is a sequence of numbers optionally followed by letters.
+	numericVersion struct {
+		scheme  string
+		parts   []int
+		letters string
+	}
+)
+
+func (s numericScheme) Name() string {
+	return s.name
+}
+
+func (s numericScheme) Parse(text string) (Version, error) {
+	// Security advisories and snapshots are not releases
+	if junkPrefixMatcher.MatchString(text) {
+		return nil, fmt.Errorf("%s is not a release: %w", text, ErrVersionScheme)
+	}
+
+	trimmed := versionPrefixMatcher.ReplaceAllString(text, "")
+	if !s.matcher.MatchString(trimmed) {
+		return nil, fmt.Errorf("%s is not a %s version: %w", text, s.name, ErrVersionScheme)
+	}
+
+	v := numericVersion{scheme: s.name}
+
+	// Separate out any letters of an OpenSSL version
+	digits := strings.TrimRightFunc(trimmed, func(r rune) bool { return r >= 'a' && r <= 'z' })
+	v.letters = trimmed[len(digits):]
+
+	// A compact date is split into its components
+	if s.name == SchemeDate && len(digits) >= 8 && !numericSeparators.MatchString(digits[:8]) {
+		digits = digits[:4] + "." + digits[4:6] + "." + digits[6:]
+	}
+
+	for _, part := range numericSeparators.Split(digits, -1) {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a %s version: %w", text, s.name, ErrVersionScheme)
+		}
+
+		v.parts = append(v.parts, n)
+	}
+
+	if s.name == SchemeDate && (v.parts[1] < 1 || v.parts[1] > 12 || v.parts[2] < 1 || v.parts[2] > 31) {
+		return nil, fmt.Errorf("%s is not a valid date: %w", text, ErrVersionScheme)
+	}
+
+	if s.name == SchemeCalVer && (v.parts[1] < 1 || v.parts[1] > 12) {
+		return nil, fmt.Errorf("%s does not have a valid month: %w", text, ErrVersionScheme)
+	}
+
+	return v, nil
+}
+
+func (v numericVersion) String() string {
+	parts := make([]string, len(v.parts))
+	for i, part := range v.parts {
+		if (v.scheme == SchemeDate && i > 0 && i < 3) || (v.scheme == SchemeCalVer && i == 1) {
+			parts[i] = fmt.Sprintf("%02d", part)
+		} else {
+			parts[i] = strconv.Itoa(part)
+		}
+	}
+
+	if v.scheme == SchemeDate {
+		s := strings.Join(parts[:3], "-")
+		if len(parts) > 3 {
+			s += "." + strings.Join(parts[3:], ".")
+		}
+
+		return s
+	}
+
+	return strings.Join(parts, ".") + v.letters
+}
+
+func (v numericVersion) Compare(other Version) int {
+	o, ok := other.(numericVersion)
+	if !ok || o.scheme != v.scheme {
+		return 0
+	}
+
+	// Missing components are treated as zero so 1.2 and 1.2.0 are equal
+	for i := range max(len(v.parts), len(o.parts)) {
+		if c := cmp.Compare(partOrZero(v.parts, i), partOrZero(o.parts, i)); c != 0 {
+			return c
+		}
+	}
+
+	// Letter releases are ordered a, b, ..., z, za, zb, ...
+	if c := cmp.Compare(len(v.letters), len(o.letters)); c != 0 {
+		return c
+	}
+
+	return strings.Compare(v.letters, o.letters)
+}
+
+func (v numericVersion) Prerelease() string {
+	return ""
+}
+
+func partOrZero(parts []int, i int) int {
+	if i < len(parts) {
+		return parts[i]
+	}
+
+	return 0
+}
+
+// Constraint restricts the versions of a Scheme.
+//
+// Constraints of the semver scheme support the full syntax of
+// github.com/Masterminds/semver. Other schemes support comparisons using =,
+// !=, >, >=, < and <= separated by commas, which must all hold, and ||, of
+// which one must hold. The ranges ~1.2.3, which allows 1.2.x, ^1.2.3, which
+// allows 1.x, and the wildcard 1.2.x are also supported.
+type Constraint struct {
+	semVer *semver.Constraints
+	groups [][]versionComparison
+}
+
+type versionComparison struct {
+	op      string
+	version Version
+}
+
+var comparisonMatcher = regexp.MustCompile(`^\s*(==|!=|>=|<=|=|>|<|~|\^)?\s*(\S+)\s*$`)
+
+// NewConstraint parses a constraint for versions of the scheme.
+func NewConstraint(scheme Scheme, c string) (*Constraint, error) {
+	constraint := &Constraint{}
+
+	if scheme.Name() == SchemeSemVer {
+		semVer, err := semver.NewConstraint(c)
+		if err != nil {
+			return nil, err
+		}
+
+		constraint.semVer = semVer
+
+		return constraint, nil
+	}
+
+	for _, group := range strings.Split(c, "||") {
+		var comparisons []versionComparison
+
+		for _, text := range strings.Split(group, ",") {
+			match := comparisonMatcher.FindStringSubmatch(text)
+			if match == nil {
+				return nil, fmt.Errorf("invalid constraint %s: %w", text, ErrVersionScheme)
+			}
+
+			parsed, err := parseComparison(scheme, match[1], match[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid constraint %s: %w", text, err)
+			}
+
+			comparisons = append(comparisons, parsed...)
+		}
+
+		constraint.groups = append(constraint.groups, comparisons)
+	}
+
+	return constraint, nil
+}
+
+// parseComparison reads a single comparison. Ranges are expanded into a lower
+// and an upper bound.
+func parseComparison(scheme Scheme, op, text string) ([]versionComparison, error) {
+	// A wildcard allows any version starting with the given components
+	wildcard := wildcardMatcher.FindStringSubmatch(text)
+	if wildcard != nil {
+		if op != "" && op != "=" && op != "==" {
+			return nil, fmt.Errorf("the wildcard %s cannot be used with %s: %w", text, op, ErrVersionScheme)
+		}
+		if wildcard[1] == "" {
+			return []versionComparison{{op: "*"}}, nil
+		}
+
+		text, op = wildcard[1], "~"
+	}
+
+	version, err := scheme.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if op != "~" && op != "^" {
+		return []versionComparison{{op: op, version: version}}, nil
+	}
+
+	numeric, ok := version.(numericVersion)
+	if !ok {
+		return nil, fmt.Errorf("the range %s%s is not supported by the %s scheme: %w", op, text, scheme.Name(), ErrVersionScheme)
+	}
+
+	// A caret keeps the major version, a tilde also keeps the minor version
+	// and a wildcard keeps every component given
+	index := 0
+	switch {
+	case wildcard != nil:
+		index = len(numeric.parts) - 1
+	case op == "~":
+		index = min(1, len(numeric.parts)-1)
+	}
+
+	upper := numericVersion{scheme: numeric.scheme, parts: make([]int, len(numeric.parts))}
+	copy(upper.parts, numeric.parts[:index])
+	upper.parts[index] = numeric.parts[index] + 1
+
+	return []versionComparison{{op: ">=", version: version}, {op: "<", version: upper}}, nil
+}
+
+// Check determines if the version satisfies the constraint.
+func (c *Constraint) Check(v Version) bool {
+	if c.semVer != nil {
+		semVer, ok := v.(semverVersion)
+		if !ok {
+			return false
+		}
+
+		return c.semVer.Check(semVer.Version)
+	}
+
+	return slices.ContainsFunc(c.groups, func(comparisons []versionComparison) bool {
+		for _, comparison := range comparisons {
+			if !comparison.check(v) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func (c versionComparison) check(v Version) bool {
+	if c.op == "*" {
+		return true
+	}
+
+	// Versions of another scheme satisfy no comparison
+	if !Comparable(v, c.version) {
+		return false
+	}
+
+	result := v.Compare(c.version)
+
+	switch c.op {
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	default:
+		return result == 0
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"testing"
+)
+
+// mustParse reads the version failing the test on an error.
+func mustParse(t *testing.T, scheme, s string) Version {
+	t.Helper()
+
+	sch, err := SchemeFromName(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := sch.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return v
+}
+
+func TestSchemeParse(t *testing.T) {
+	tests := []struct {
+		scheme   string
+		text     string
+		expected string
+	}{
+		{SchemeCalVer, "2024.01", "2024.01"},
+		{SchemeCalVer, "v2024.1.15", "2024.01.15"},
+		{SchemeCalVer, "24.04", "24.04"},
+		{SchemeCalVer, "2024.13", ""},
+		{SchemeCalVer, "1.2.3", ""},
+		{SchemeDate, "20240115", "2024-01-15"},
+		{SchemeDate, "2024-01-15", "2024-01-15"},
+		{SchemeDate, "2024.01.15.2", "2024-01-15.2"},
+		{SchemeDate, "20241315", ""},
+		{SchemeDate, "2024-01", ""},
+		{SchemeDotted, "1.2.3.4", "1.2.3.4"},
+		{SchemeDotted, "v1_26_0", "1.26.0"},
+		{SchemeDotted, "8", "8"},
+		{SchemeDotted, "2.0.0-rc1", ""},
+		{SchemeDotted, "1.2a", ""},
+		{SchemeOpenSSL, "1.1.1w", "1.1.1w"},
+		{SchemeOpenSSL, "OpenSSL_1_1_1za", "1.1.1za"},
+		{SchemeOpenSSL, "3.2.0", "3.2.0"},
+		{SchemeOpenSSL, "3.2.0-alpha1", ""},
+		// Only a leading v or project name is removed
+		{SchemeDotted, "curl-8_5_0", "8.5.0"},
+		{SchemeDotted, "release-74-2", "74.2"},
+		{SchemeDotted, "VER-2-13-2", "2.13.2"},
+		{SchemeDotted, "libjpeg-turbo-3.0.1", "3.0.1"},
+		{SchemeDotted, "build123", ""},
+		{SchemeDotted, "CVE-2021-3520", ""},
+		{SchemeDotted, "1.2.3 (stable)", ""},
+		{SchemeDate, "snapshot-20230101", ""},
+		{SchemeDate, "nightly-2023-01-01", ""},
+		{SchemeCalVer, "cve-2024-01", ""},
+	}
+
+	for _, test := range tests {
+		scheme, err := SchemeFromName(test.scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		v, err := scheme.Parse(test.text)
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("%s %s: expected an error, got %s", test.scheme, test.text, v)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s %s: %v", test.scheme, test.text, err)
+		} else if v.String() != test.expected {
+			t.Errorf("%s %s: expected %s, got %s", test.scheme, test.text, test.expected, v)
+		}
+	}
+
+	if _, err := SchemeFromName("roman"); err == nil {
+		t.Error("expected an error for an unknown scheme")
+	}
+}
+
+func TestSchemeCompare(t *testing.T) {
+	tests := []struct {
+		scheme   string
+		a        string
+		b        string
+		expected int
+	}{
+		{SchemeDotted, "1.2", "1.2.0", 0},
+		{SchemeDotted, "1.10", "1.9", 1},
+		{SchemeDotted, "9.0", "10.0", -1},
+		{SchemeOpenSSL, "1.1.1w", "1.1.1za", -1},
+		{SchemeOpenSSL, "1.1.1", "1.1.1a", -1},
+		{SchemeOpenSSL, "1.1.1z", "1.1.1y", 1},
+		{SchemeDate, "20240115", "2024-01-15", 0},
+		{SchemeDate, "2024-01-15", "2024-01-15.1", -1},
+		{SchemeDate, "20231231", "20240101", -1},
+		{SchemeCalVer, "2024.1", "2024.01.0", 0},
+		{SchemeSemVer, "1.2.3", "1.2.3-rc.1", 1},
+	}
+
+	for _, test := range tests {
+		a := mustParse(t, test.scheme, test.a)
+		b := mustParse(t, test.scheme, test.b)
+
+		if c := a.Compare(b); c != test.expected {
+			t.Errorf("%s %s %s: expected %d, got %d", test.scheme, test.a, test.b, test.expected, c)
+		}
+		if c := b.Compare(a); c != -test.expected {
+			t.Errorf("%s %s %s: expected %d, got %d", test.scheme, test.b, test.a, -test.expected, c)
+		}
+	}
+}
+
+func TestComparable(t *testing.T) {
+	dotted := mustParse(t, SchemeDotted, "10.0")
+	openssl := mustParse(t, SchemeOpenSSL, "9.0")
+	semVer := mustParse(t, SchemeSemVer, "9.0.0")
+
+	if !Comparable(dotted, mustParse(t, SchemeDotted, "9.0")) {
+		t.Error("expected versions of the same scheme to be comparable")
+	}
+
+	// The string forms are never compared
+	for _, other := range []Version{openssl, semVer} {
+		if Comparable(dotted, other) || Comparable(other, dotted) {
+			t.Errorf("expected %s and %s not to be comparable", dotted, other)
+		}
+		if dotted.Compare(other) != 0 || other.Compare(dotted) != 0 {
+			t.Errorf("expected %s and %s to compare as equal", dotted, other)
+		}
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	tests := []struct {
+		scheme     string
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{SchemeDotted, ">= 1.2", "1.2.0", true},
+		{SchemeDotted, ">= 1.2", "1.10", true},
+		{SchemeDotted, "> 1.2", "1.2.0", false},
+		{SchemeDotted, "1.2", "1.2.0", true},
+		{SchemeDotted, "== 1.2", "1.3", false},
+		{SchemeDotted, "!= 1.2", "1.3", true},
+		{SchemeDotted, ">= 1.2, < 2", "1.9.9", true},
+		{SchemeDotted, ">= 1.2, < 2", "2.0", false},
+		{SchemeDotted, "< 1 || >= 2, < 3", "0.9", true},
+		{SchemeDotted, "< 1 || >= 2, < 3", "1.5", false},
+		{SchemeDotted, "< 1 || >= 2, < 3", "2.5", true},
+		{SchemeDotted, "< 1 || >= 2, < 3", "3", false},
+		{SchemeOpenSSL, ">= 1.1.1w", "1.1.1za", true},
+		{SchemeOpenSSL, "<= 1.1.1w", "1.1.1za", false},
+		{SchemeDate, ">= 2024-01-15", "20240116", true},
+		{SchemeDate, ">= 2024-01-15", "20231231", false},
+		{SchemeDotted, "~1.2.3", "1.2.9", true},
+		{SchemeDotted, "~1.2.3", "1.3", false},
+		{SchemeDotted, "~1.2.3", "1.2.2", false},
+		{SchemeDotted, "~1.2", "1.2.5", true},
+		{SchemeDotted, "~1.2", "1.3.0", false},
+		{SchemeDotted, "~1", "1.9", true},
+		{SchemeDotted, "~1", "2.0", false},
+		{SchemeDotted, "^1.2.3", "1.9.0", true},
+		{SchemeDotted, "^1.2.3", "2.0.0", false},
+		{SchemeDotted, "^ 1.2.3", "1.2.2", false},
+		{SchemeDotted, "1.2.x", "1.2.7", true},
+		{SchemeDotted, "1.2.x", "1.3", false},
+		{SchemeDotted, "= 1.x", "1.99", true},
+		{SchemeDotted, "1.*", "2.0", false},
+		{SchemeDotted, "*", "0.1", true},
+		{SchemeOpenSSL, "~1.1.1w", "1.1.1za", true},
+		{SchemeOpenSSL, "~1.1.1w", "1.1.1v", false},
+		{SchemeOpenSSL, "~1.1.1w", "1.2.0", false},
+		{SchemeCalVer, "^2024.01", "2024.12", true},
+		{SchemeCalVer, "^2024.01", "2025.01", false},
+		{SchemeSemVer, ">= 1.2.3", "1.2.4", true},
+		{SchemeSemVer, "~1.2.3", "1.3.0", false},
+	}
+
+	for _, test := range tests {
+		scheme, err := SchemeFromName(test.scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := NewConstraint(scheme, test.constraint)
+		if err != nil {
+			t.Errorf("%s %s: %v", test.scheme, test.constraint, err)
+			continue
+		}
+
+		if c.Check(mustParse(t, test.scheme, test.version)) != test.expected {
+			t.Errorf("%s %s %s: expected %v", test.scheme, test.constraint, test.version, test.expected)
+		}
+	}
+}
+
+func TestConstraintOtherScheme(t *testing.T) {
+	dotted, err := SchemeFromName(SchemeDotted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewConstraint(dotted, ">= 1.0 || != 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Versions of another scheme never satisfy a constraint
+	for _, v := range []Version{mustParse(t, SchemeSemVer, "2.0.0-rc1"), mustParse(t, SchemeOpenSSL, "1.1.1w")} {
+		if c.Check(v) {
+			t.Errorf("expected %s not to satisfy %s", v, ">= 1.0 || != 3")
+		}
+	}
+
+	semVer, err := NewConstraint(schemes[SchemeSemVer], ">= 1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if semVer.Check(mustParse(t, SchemeDotted, "2.0")) {
+		t.Error("expected a dotted version not to satisfy a semver constraint")
+	}
+}
+
+func TestConstraintErrors(t *testing.T) {
+	tests := []struct {
+		scheme     string
+		constraint string
+	}{
+		{SchemeDotted, ">= 1.2 1.3"},
+		{SchemeDotted, ">= 1.2a"},
+		{SchemeDotted, ">= rc1"},
+		{SchemeDotted, ">= CVE-2021-3520"},
+		{SchemeDotted, ">= 1.2.x"},
+		{SchemeDotted, "!= 1.x"},
+		{SchemeDotted, "~1.x.3"},
+		{SchemeDotted, ">= 1.2,"},
+		{SchemeDate, ">= 2024-13-01"},
+		{SchemeSemVer, ">= one"},
+	}
+
+	for _, test := range tests {
+		scheme, err := SchemeFromName(test.scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = NewConstraint(scheme, test.constraint); err == nil {
+			t.Errorf("%s: expected an error for %s", test.scheme, test.constraint)
+		}
+	}
+}
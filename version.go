@@ -5,6 +5,7 @@
 package reqcheck
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -13,16 +14,142 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+var ErrVersionPattern = errors.New("version pattern error")
+
 var versionMatcher = regexp.MustCompile(`^[a-zA-Z-_.]*(?P<major>\d+)[._-](?P<minor>\d*)[._-]*(?P<patch>\d*)[._-]*(?P<prerelease>[a-zA-Z-_.]*)(?P<preversion>\d*)$`)
 
+// VersionPattern describes how the version is extracted from a tag.
+type VersionPattern struct {
+	// Matcher captures the version within the tag. Semantic versions can be
+	// captured using the named groups major, minor, patch, prerelease and
+	// preversion, where missing or empty groups default to zero for major,
+	// minor and patch. Otherwise the version is captured by the named group
+	// version and read by the Scheme.
+	Matcher *regexp.Regexp
+	// Prefix is removed from the tag before matching.
+	Prefix string
+	// Suffix is removed from the tag before matching.
+	Suffix string
+	// Exclude lists patterns for tags that are never versions.
+	Exclude []*regexp.Regexp
+}
+
+// NewVersionPattern compiles a VersionPattern. When the pattern is empty the
+// default matcher is used.
+func NewVersionPattern(pattern, prefix, suffix string, exclude []string) (*VersionPattern, error) {
+	p := &VersionPattern{
+		Matcher: versionMatcher,
+		Prefix:  prefix,
+		Suffix:  suffix,
+		Exclude: make([]*regexp.Regexp, 0, len(exclude)),
+	}
+
+	if pattern != "" {
+		matcher, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse version pattern %s: %w", pattern, err)
+		}
+
+		if matcher.SubexpIndex("major") < 0 && matcher.SubexpIndex("version") < 0 {
+			return nil, fmt.Errorf("version pattern %s has no major or version group: %w", pattern, ErrVersionPattern)
+		}
+
+		p.Matcher = matcher
+	}
+
+	for _, e := range exclude {
+		matcher, err := regexp.Compile(e)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse exclude pattern %s: %w", e, err)
+		}
+
+		p.Exclude = append(p.Exclude, matcher)
+	}
+
+	return p, nil
+}
+
+// Version extracts the semantic version from the tag. Returns nil when the
+// tag is excluded or not a version.
+func (p *VersionPattern) Version(tag string) *semver.Version {
+	text, ok := p.extract(tag)
+	if !ok {
+		return nil
+	}
+
+	if p.Matcher != nil && p.Matcher.SubexpIndex("major") >= 0 {
+		return generateVersion(text, p.Matcher)
+	}
+
+	return generateVersion(text, versionMatcher)
+}
+
+// Parse extracts the version of the scheme from the tag. Returns nil when the
+// tag is excluded or not a version.
+func (p *VersionPattern) Parse(scheme Scheme, tag string) Version {
+	if scheme.Name() == SchemeSemVer {
+		if semVer := p.Version(tag); semVer != nil {
+			return SemVerVersion(semVer)
+		}
+
+		return nil
+	}
+
+	text, ok := p.extract(tag)
+	if !ok {
+		return nil
+	}
+
+	version, err := scheme.Parse(text)
+	if err != nil {
+		return nil
+	}
+
+	return version
+}
+
+// extract applies the exclusions and removes the prefix and suffix from the
+// tag. When the matcher has a version group only its contents are returned.
+func (p *VersionPattern) extract(tag string) (string, bool) {
+	for _, exclude := range p.Exclude {
+		if exclude.MatchString(tag) {
+			logrus.WithField("tag", tag).Debug("tag excluded")
+
+			return "", false
+		}
+	}
+
+	tag = strings.TrimPrefix(tag, p.Prefix)
+	tag = strings.TrimSuffix(tag, p.Suffix)
+
+	if p.Matcher == nil {
+		return tag, true
+	}
+
+	versionIndex := p.Matcher.SubexpIndex("version")
+	if versionIndex < 0 {
+		return tag, true
+	}
+
+	match := p.Matcher.FindStringSubmatch(tag)
+	if match == nil {
+		return "", false
+	}
+
+	return match[versionIndex], true
+}
+
 func generateVersion(tag string, matcher *regexp.Regexp) *semver.Version {
 	if strings.HasPrefix(tag, "CVE-") {
 		return nil
 	}
 
-	semVer, err := semver.NewVersion(tag)
-	if err == nil {
-		return semVer
+	// A custom matcher decides on its own what is a version
+	if matcher == versionMatcher {
+		semVer, err := semver.NewVersion(tag)
+		if err == nil {
+			return semVer
+		}
 	}
 
 	match := matcher.FindStringSubmatch(tag)
@@ -30,19 +157,30 @@ func generateVersion(tag string, matcher *regexp.Regexp) *semver.Version {
 		return nil
 	}
 
-	major := match[1]
+	group := func(name string) string {
+		if i := matcher.SubexpIndex(name); i >= 0 {
+			return match[i]
+		}
+
+		return ""
+	}
+
+	major := group("major")
+	if major == "" {
+		major = "0"
+	}
 
-	minor := match[2]
+	minor := group("minor")
 	if minor == "" {
 		minor = "0"
 	}
 
-	patch := match[3]
+	patch := group("patch")
 	if patch == "" {
 		patch = "0"
 	}
 
-	prerelease := match[4]
+	prerelease := group("prerelease")
 	if prerelease != "" {
 		if prerelease == "." {
 			prerelease = "build"
@@ -50,20 +188,20 @@ func generateVersion(tag string, matcher *regexp.Regexp) *semver.Version {
 
 		prerelease = "-" + prerelease
 
-		preVersion := match[5]
+		preVersion := group("preversion")
 		if preVersion != "" {
 			prerelease += "." + preVersion
 		}
 	}
 
-	semVer, err = semver.NewVersion(fmt.Sprintf("%s.%s.%s%s", major, minor, patch, prerelease))
+	semVer, err := semver.NewVersion(fmt.Sprintf("%s.%s.%s%s", major, minor, patch, prerelease))
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"major":      major,
 			"minor":      minor,
 			"patch":      patch,
-			"prerelease": match[4],
-			"preversion": match[5],
+			"prerelease": group("prerelease"),
+			"preversion": group("preversion"),
 		}).Warn("could not parse version")
 
 		return nil
@@ -0,0 +1,94 @@
+// Copyright (c) 2026, the WebKit for Windows project authors.  Please see the
+// AUTHORS file for details. All rights reserved. Use of this source code is
+// governed by a BSD-style license that can be found in the LICENSE file.
+
+package reqcheck
+
+import (
+	"testing"
+)
+
+func TestVersionPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		prefix   string
+		suffix   string
+		exclude  []string
+		tag      string
+		expected string
+	}{
+		// The default matcher
+		{"", "", "", nil, "curl-8_5_0", "8.5.0"},
+		{"", "", "", nil, "release-74-2", "74.2.0"},
+		{"", "", "", nil, "VER-2-13-2", "2.13.2"},
+		{"", "", "", nil, "cares-1_26_0", "1.26.0"},
+		{"", "", "", nil, "openssl-3.2.0", "3.2.0"},
+		{"", "", "", nil, "CVE-2021-3520", ""},
+		// A prefix and suffix are removed before matching
+		{`^(?P<major>\d+)_(?P<minor>\d+)_(?P<patch>\d+)$`, "curl-", "", nil, "curl-8_5_0", "8.5.0"},
+		{`^(?P<major>\d+)_(?P<minor>\d+)_(?P<patch>\d+)$`, "curl-", "", nil, "tiny-curl-8_4_0", ""},
+		{`^(?P<major>\d+)-(?P<minor>\d+)$`, "release-", "", nil, "release-74-2", "74.2.0"},
+		{`^(?P<major>\d+)-(?P<minor>\d+)$`, "release-", "", nil, "release-74-rc1", ""},
+		{`^(?P<major>\d+)-(?P<minor>\d+)-(?P<patch>\d+)$`, "VER-", "", nil, "VER-2-13-2", "2.13.2"},
+		{`^(?P<major>\d+)_(?P<minor>\d+)_(?P<patch>\d+)$`, "cares-", "", nil, "cares-1_26_0", "1.26.0"},
+		{`^(?P<major>\d+)\.(?P<minor>\d+)$`, "v", "-final", nil, "v2.1-final", "2.1.0"},
+		// A prerelease is captured by its own groups
+		{`^(?P<major>\d+)_(?P<minor>\d+)_(?P<patch>\d+)(?:-(?P<prerelease>rc)(?P<preversion>\d+))?$`, "curl-", "", nil, "curl-8_5_0-rc2", "8.5.0-rc.2"},
+		// Excluded tags are never versions
+		{"", "", "", []string{`^curl-\d+_\d+_\d+-`}, "curl-8_5_0-rc2", ""},
+		{"", "", "", []string{`^release-`}, "release-74-2", ""},
+	}
+
+	for _, test := range tests {
+		pattern, err := NewVersionPattern(test.pattern, test.prefix, test.suffix, test.exclude)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var actual string
+		if version := pattern.Version(test.tag); version != nil {
+			actual = version.String()
+		}
+
+		if actual != test.expected {
+			t.Errorf("%s with pattern %q: expected %q, got %q", test.tag, test.pattern, test.expected, actual)
+		}
+	}
+}
+
+func TestVersionPatternGroup(t *testing.T) {
+	pattern, err := NewVersionPattern(`^cares-(?P<version>\d+_\d+_\d+)$`, "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scheme, err := SchemeFromName(SchemeDotted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the version group is read by the scheme
+	if version := pattern.Parse(scheme, "cares-1_26_0"); version == nil || version.String() != "1.26.0" {
+		t.Errorf("unexpected version %v", version)
+	}
+	if version := pattern.Parse(scheme, "c-ares-1_26_0"); version != nil {
+		t.Errorf("expected no version, got %v", version)
+	}
+}
+
+func TestNewVersionPatternErrors(t *testing.T) {
+	tests := []struct {
+		pattern string
+		exclude []string
+	}{
+		{`^(?P<major>\d+`, nil},
+		{`^(\d+)\.(\d+)$`, nil},
+		{"", []string{`^[`}},
+	}
+
+	for _, test := range tests {
+		if _, err := NewVersionPattern(test.pattern, "", "", test.exclude); err == nil {
+			t.Errorf("expected an error for %q %v", test.pattern, test.exclude)
+		}
+	}
+}